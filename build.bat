@@ -28,8 +28,8 @@ echo Mode: Clean build with NSIS installer
 echo.
 
 REM Run the Wails build command
-echo Running: wails build --clean --nsis
-wails build --clean --nsis
+echo Running: wails build --clean --nsis -tags sqlite_fts5
+wails build --clean --nsis -tags sqlite_fts5
 
 if %ERRORLEVEL% neq 0 (
     echo.
@@ -60,8 +60,8 @@ $buildStart = Get-Date
 
 # Run the Wails build command
 try {
-    Write-Host "Running: wails build --clean --nsis" -ForegroundColor Blue
-    wails build --clean --nsis
+    Write-Host "Running: wails build --clean --nsis -tags sqlite_fts5" -ForegroundColor Blue
+    wails build --clean --nsis -tags sqlite_fts5
     
     if ($LASTEXITCODE -ne 0) {
         throw "Build failed with exit code $LASTEXITCODE"
@@ -2,28 +2,79 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"trading-dashboard/pkg/api"
 	"trading-dashboard/pkg/database"
+	"trading-dashboard/pkg/importer"
 	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/occ"
+	"trading-dashboard/pkg/payoff"
+	"trading-dashboard/pkg/pricing"
 	"trading-dashboard/pkg/services"
 )
 
-// App struct
+// App struct. mu guards the database and services, which RestoreBackup
+// replaces: bindings hold the read lock while they use them, taking it only
+// after any file dialog so an open dialog cannot hold up a restore.
 type App struct {
 	ctx           context.Context
+	mu            sync.RWMutex
 	db            *database.DB
 	marketService *services.MarketService
 	tradeService  *services.TradeService
+	analytics     *services.AnalyticsService
+	settings      *services.SettingsService
+	archives      *services.ArchiveService
+	workbooks     *services.WorkbookService
+	reports       *services.ReportService
+	portfolio     *services.PortfolioService
+	betas         *services.BetaService
+	sizing        *services.SizingService
+	alerts        *services.AlertService
+	backups       *services.BackupService
+	dbPath        string
+	apiConfig     *api.Config
+	apiServer     *api.Server
+	stopSweeper   context.CancelFunc
+	stopBackups   context.CancelFunc
+	stopAlerts    context.CancelFunc
+	background    sync.WaitGroup
 }
 
-// NewApp creates a new App application struct
-func NewApp() *App {
-	return &App{}
+// expirationSweepInterval is how often active trades are checked for expiration
+const expirationSweepInterval = time.Hour
+
+// eventTradesExpired is emitted with the []models.StatusChange produced when
+// trades are expired, so the grid can refresh
+const eventTradesExpired = "trades:expired"
+
+// alertCheckInterval is how often the alert rules are checked
+const alertCheckInterval = 5 * time.Minute
+
+// eventAlertsNew is emitted with the []models.Alert added by each alert
+// check, so the frontend can show them
+const eventAlertsNew = "alerts:new"
+
+// backupInterval is how often the database is backed up while the app runs
+const backupInterval = 24 * time.Hour
+
+// eventDatabaseRestored is emitted after a backup has been restored, so the
+// frontend can reload everything it has cached
+const eventDatabaseRestored = "database:restored"
+
+// NewApp creates a new App application struct. A non-nil apiConfig also
+// serves the REST API once the database is ready.
+func NewApp(apiConfig *api.Config) *App {
+	return &App{apiConfig: apiConfig}
 }
 
 // getConsistentDataDir returns a consistent data directory that works for both standalone and installed versions
@@ -94,33 +145,164 @@ func (a *App) startup(ctx context.Context) {
 	dbPath := filepath.Join(dataDir, "trading_dashboard.db")
 	log.Printf("Initializing database at: %s", dbPath)
 
-	db, err := database.NewDB(dbPath)
-	if err != nil {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.dbPath = dbPath
+	if err := a.openDatabase(); err != nil {
 		log.Printf("Failed to initialize database: %v", err)
-		// Still try to initialize services with nil database for graceful failure
-		a.db = nil
-		a.marketService = nil
-		a.tradeService = nil
 		return
 	}
 
+	// Back up on every launch, then on a schedule
+	a.startBackground(true)
+
+	log.Println("Trading Dashboard initialized successfully")
+}
+
+// openDatabase opens and migrates the database at a.dbPath and creates the
+// services. On failure the services stay nil so bindings fail gracefully.
+// The caller holds a.mu for writing.
+func (a *App) openDatabase() error {
+	a.db = nil
+	a.marketService = nil
+	a.tradeService = nil
+	a.analytics = nil
+	a.settings = nil
+	a.archives = nil
+	a.workbooks = nil
+	a.reports = nil
+	a.portfolio = nil
+	a.betas = nil
+	a.sizing = nil
+	a.alerts = nil
+	a.backups = nil
+
+	db, err := database.NewDB(a.dbPath)
+	if err != nil {
+		return err
+	}
+
 	// Initialize schema with better error handling
 	log.Println("Initializing database schema...")
 	if err := db.InitSchema(); err != nil {
-		log.Printf("Failed to initialize schema: %v", err)
-		// Close the database and set services to nil
+		if errors.Is(err, database.ErrSchemaTooNew) {
+			log.Printf("Refusing to open %s: it was created by a newer version of Trading Dashboard", a.dbPath)
+		}
 		db.Close()
-		a.db = nil
-		a.marketService = nil
-		a.tradeService = nil
-		return
+		return fmt.Errorf("failed to initialize schema: %w", err)
 	}
 
 	a.db = db
 	a.marketService = services.NewMarketService(db.DB)
 	a.tradeService = services.NewTradeService(db.DB)
+	a.analytics = services.NewAnalyticsService(db.DB)
+	a.settings = services.NewSettingsService(db.DB)
+	a.archives = services.NewArchiveService(db.DB)
+	a.workbooks = services.NewWorkbookService(db.DB)
+	a.reports = services.NewReportService(db.DB)
+	a.portfolio = services.NewPortfolioService(db.DB)
+	a.betas = services.NewBetaService(db.DB)
+	a.sizing = services.NewSizingService(db.DB)
+	a.alerts = services.NewAlertService(db.DB)
+	a.backups = services.NewBackupService(db, filepath.Join(filepath.Dir(a.dbPath), "backups"), models.DefaultBackupRetention)
+	return nil
+}
 
-	log.Println("Trading Dashboard initialized successfully")
+// startBackground starts the expiration sweeper, the alert scheduler, the
+// backup scheduler and, when configured, the REST API
+func (a *App) startBackground(startupBackup bool) {
+	trades, alerts, backups := a.tradeService, a.alerts, a.backups
+
+	sweepCtx, cancel := context.WithCancel(a.ctx)
+	a.stopSweeper = cancel
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		trades.RunExpirationSweeper(sweepCtx, expirationSweepInterval, a.notifyExpired)
+	}()
+
+	alertCtx, cancel := context.WithCancel(a.ctx)
+	a.stopAlerts = cancel
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		alerts.RunAlertScheduler(alertCtx, alertCheckInterval, a.notifyAlerts)
+	}()
+
+	backupCtx, cancel := context.WithCancel(a.ctx)
+	a.stopBackups = cancel
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		backups.RunBackupScheduler(backupCtx, backupInterval, startupBackup)
+	}()
+
+	if a.apiConfig != nil {
+		a.startAPIServer()
+	}
+}
+
+// stopBackground stops everything started by startBackground and waits for
+// it to finish, so nothing is using the database afterwards
+func (a *App) stopBackground(ctx context.Context) {
+	if a.stopSweeper != nil {
+		a.stopSweeper()
+		a.stopSweeper = nil
+	}
+	if a.stopAlerts != nil {
+		a.stopAlerts()
+		a.stopAlerts = nil
+	}
+	if a.stopBackups != nil {
+		a.stopBackups()
+		a.stopBackups = nil
+	}
+	if a.apiServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if err := a.apiServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Failed to stop REST API: %v", err)
+		}
+		a.apiServer = nil
+	}
+	a.background.Wait()
+}
+
+// startAPIServer serves the REST API in the background; failures are logged
+// so the desktop window still works
+func (a *App) startAPIServer() {
+	server := api.NewServer(*a.apiConfig, api.Services{
+		Market:    a.marketService,
+		Trades:    a.tradeService,
+		Analytics: a.analytics,
+		Alerts:    a.alerts,
+	})
+	if err := server.Start(); err != nil {
+		log.Printf("Failed to start REST API: %v", err)
+		return
+	}
+	a.apiServer = server
+}
+
+// notifyExpired tells the frontend that trades were expired
+func (a *App) notifyExpired(changes []models.StatusChange) {
+	runtime.EventsEmit(a.ctx, eventTradesExpired, changes)
+}
+
+// notifyAlerts tells the frontend about new alerts
+func (a *App) notifyAlerts(alerts []models.Alert) {
+	runtime.EventsEmit(a.ctx, eventAlertsNew, alerts)
+}
+
+// shutdown is called when the app is closing. It stops the background
+// work and REST API and closes the database.
+func (a *App) shutdown(ctx context.Context) {
+	a.mu.Lock()
+	a.stopBackground(ctx)
+	a.mu.Unlock()
+
+	a.Close()
 }
 
 // Greet returns a greeting for the given name
@@ -130,6 +312,9 @@ func (a *App) Greet(name string) string {
 
 // SaveMarketRating saves a new market rating with sector ratings
 func (a *App) SaveMarketRating(req models.MarketRatingRequest) (*models.MarketRating, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	if a.marketService == nil {
 		return nil, fmt.Errorf("market service not available - database connection failed")
 	}
@@ -138,6 +323,9 @@ func (a *App) SaveMarketRating(req models.MarketRatingRequest) (*models.MarketRa
 
 // GetLatestMarketRating retrieves the most recent market rating
 func (a *App) GetLatestMarketRating() (*models.MarketRating, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	if a.marketService == nil {
 		log.Printf("Market service not initialized - database connection failed")
 		// Return a default rating instead of failing
@@ -154,18 +342,61 @@ func (a *App) GetLatestMarketRating() (*models.MarketRating, error) {
 
 // UpdateMarketRating updates an existing market rating
 func (a *App) UpdateMarketRating(id int64, req models.MarketRatingRequest) (*models.MarketRating, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.marketService == nil {
+		return nil, fmt.Errorf("market service not available - database connection failed")
+	}
 	return a.marketService.UpdateRating(id, req)
 }
 
+// GetMarketRatingHistory retrieves every market rating saved within a date range
+func (a *App) GetMarketRatingHistory(startDate, endDate time.Time) ([]models.MarketRating, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.marketService == nil {
+		return []models.MarketRating{}, nil
+	}
+	return a.marketService.GetRatingsBetween(startDate, endDate)
+}
+
+// GetSectorRatingHistory retrieves a sector's rating over time
+func (a *App) GetSectorRatingHistory(sector string, startDate, endDate time.Time) ([]models.SectorRatingPoint, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.marketService == nil {
+		return []models.SectorRatingPoint{}, nil
+	}
+	return a.marketService.GetSectorRatingHistory(sector, startDate, endDate)
+}
+
+// CompareMarketRatings reports the rating changes between two snapshots
+func (a *App) CompareMarketRatings(fromID, toID int64) (*models.RatingComparison, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.marketService == nil {
+		return nil, fmt.Errorf("market service not available - database connection failed")
+	}
+	return a.marketService.CompareRatings(fromID, toID)
+}
+
 // GetSectorNames returns the list of available market sectors
 func (a *App) GetSectorNames() []string {
-	return a.marketService.GetSectorNames()
+	return models.GetSectorNames()
 }
 
 // Close closes the database connection (called on app shutdown)
 func (a *App) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
 	if a.db != nil {
 		a.db.Close()
+		a.db = nil
 	}
 }
 
@@ -173,19 +404,65 @@ func (a *App) Close() {
 
 // CreateTrade creates a new options trade
 func (a *App) CreateTrade(req models.TradeRequest) (*models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	if a.tradeService == nil {
 		return nil, fmt.Errorf("trade service not available - database connection failed")
 	}
 	return a.tradeService.CreateTrade(req)
 }
 
+// CheckTradeRules lists the trade rules a request would break without
+// creating the trade
+func (a *App) CheckTradeRules(req models.TradeRequest) (*models.RuleCheck, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.CheckTradeRules(req)
+}
+
+// GetTradeRules returns the trade rules checked when a trade is created
+func (a *App) GetTradeRules() (models.TradeRules, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return models.TradeRules{}, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.GetTradeRules()
+}
+
+// SaveTradeRules stores the trade rules
+func (a *App) SaveTradeRules(rules models.TradeRules) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.SaveTradeRules(rules)
+}
+
 // GetTradeByID retrieves a trade by ID
 func (a *App) GetTradeByID(id int64) (*models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
 	return a.tradeService.GetTradeByID(id)
 }
 
 // GetTrades retrieves trades within a date range
 func (a *App) GetTrades(startDate, endDate time.Time) ([]models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	if a.tradeService == nil {
 		log.Printf("Trade service not initialized - database connection failed")
 		// Return empty list instead of failing
@@ -196,6 +473,9 @@ func (a *App) GetTrades(startDate, endDate time.Time) ([]models.OptionsTrade, er
 
 // GetActiveTradesByDateRange retrieves active trades for calendar view
 func (a *App) GetActiveTradesByDateRange(startDate, endDate time.Time) ([]models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	if a.tradeService == nil {
 		log.Printf("Trade service not initialized - database connection failed")
 		// Return empty list instead of failing
@@ -204,23 +484,107 @@ func (a *App) GetActiveTradesByDateRange(startDate, endDate time.Time) ([]models
 	return a.tradeService.GetActiveTradesByDateRange(startDate, endDate)
 }
 
+// QueryTrades returns one page of trades matching filters, sorted and paginated in the database
+func (a *App) QueryTrades(query models.TradeQuery) (*models.TradePage, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		log.Printf("Trade service not initialized - database connection failed")
+		// Return an empty page instead of failing
+		return &models.TradePage{Trades: []models.OptionsTrade{}}, nil
+	}
+	return a.tradeService.QueryTrades(query)
+}
+
+// GetTradeSortKeys returns the sort keys accepted by QueryTrades
+func (a *App) GetTradeSortKeys() []string {
+	return models.GetSortKeys()
+}
+
 // UpdateTrade updates an existing trade
 func (a *App) UpdateTrade(id int64, req models.TradeRequest) (*models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
 	return a.tradeService.UpdateTrade(id, req)
 }
 
-// UpdateTradeStatus updates the status of a trade
+// UpdateTradeStatus moves a trade to a new lifecycle status
 func (a *App) UpdateTradeStatus(id int64, status string) (*models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
 	return a.tradeService.UpdateTradeStatus(id, status)
 }
 
 // DeleteTrade deletes a trade
 func (a *App) DeleteTrade(id int64) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return fmt.Errorf("trade service not available - database connection failed")
+	}
 	return a.tradeService.DeleteTrade(id)
 }
 
+// ExpireOverdueTrades expires every open trade past its expiration close and notifies the frontend
+func (a *App) ExpireOverdueTrades() ([]models.StatusChange, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	changes, err := a.tradeService.ExpireOverdueTrades(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > 0 {
+		a.notifyExpired(changes)
+	}
+	return changes, nil
+}
+
+// SearchTrades searches tickers, sectors, strategies and notes across the whole trade history
+func (a *App) SearchTrades(query string, filters models.TradeSearchFilters) ([]models.TradeSearchResult, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return []models.TradeSearchResult{}, nil
+	}
+	return a.tradeService.SearchTrades(query, filters)
+}
+
+// GetStatusTransitions returns the statuses each trade status may move to
+func (a *App) GetStatusTransitions() map[string][]string {
+	return models.GetStatusTransitions()
+}
+
+// GetTradeStatusHistory retrieves the status transitions of a trade
+func (a *App) GetTradeStatusHistory(tradeID int64) ([]models.StatusChange, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return []models.StatusChange{}, nil
+	}
+	return a.tradeService.GetStatusHistory(tradeID)
+}
+
 // GetStrategyTypes retrieves all available strategy types
 func (a *App) GetStrategyTypes() ([]models.StrategyType, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	if a.tradeService == nil {
 		log.Printf("Trade service not initialized - database connection failed")
 		// Return empty list instead of failing
@@ -228,3 +592,679 @@ func (a *App) GetStrategyTypes() ([]models.StrategyType, error) {
 	}
 	return a.tradeService.GetStrategyTypes()
 }
+
+// ============ FILLS & P&L API METHODS ============
+
+// AddTradeFill records an opening or closing fill against an open trade
+func (a *App) AddTradeFill(tradeID int64, req models.FillRequest) (*models.Fill, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.AddFill(tradeID, req)
+}
+
+// GetTradeFills retrieves all fills recorded for a trade
+func (a *App) GetTradeFills(tradeID int64) ([]models.Fill, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return []models.Fill{}, nil
+	}
+	return a.tradeService.GetFills(tradeID)
+}
+
+// DeleteTradeFill deletes a fill from an open trade
+func (a *App) DeleteTradeFill(id int64) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.DeleteFill(id)
+}
+
+// CloseTrade records a closing fill and marks the trade as closed once nothing remains open
+func (a *App) CloseTrade(tradeID int64, req models.FillRequest) (*models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.CloseTrade(tradeID, req)
+}
+
+// GetTradePnL computes realized and, given a mark price, unrealized P&L for a trade
+func (a *App) GetTradePnL(tradeID int64, mark *float64) (*models.TradePnL, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.GetTradePnL(tradeID, mark)
+}
+
+// RollTrade closes an open trade and opens its replacement, linking the two
+func (a *App) RollTrade(tradeID int64, req models.RollRequest) (*models.OptionsTrade, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.RollTrade(tradeID, req)
+}
+
+// GetRollChain computes the P&L of a trade's roll chain rolled up to the original trade
+func (a *App) GetRollChain(tradeID int64, mark *float64) (*models.RollChain, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.GetRollChain(tradeID, mark)
+}
+
+// GetRealizedPnL computes realized P&L across all trades within a date range
+func (a *App) GetRealizedPnL(startDate, endDate time.Time) (*models.PnLSummary, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.tradeService.GetRealizedPnL(startDate, endDate)
+}
+
+// ============ PRICING API METHODS ============
+
+// CalculateOptionGreeks prices a single European option and returns its Greeks
+func (a *App) CalculateOptionGreeks(in pricing.Inputs) (pricing.Greeks, error) {
+	return pricing.Calculate(in)
+}
+
+// SolveImpliedVolatility returns the volatility implied by an option premium
+func (a *App) SolveImpliedVolatility(in pricing.Inputs, premium float64) (float64, error) {
+	return pricing.ImpliedVolatility(in, premium)
+}
+
+// CalculateTradeGreeks values a trade's legs at the given underlying price,
+// implied volatility and rate, using each leg's expiration date
+func (a *App) CalculateTradeGreeks(tradeID int64, req models.GreeksRequest) (*models.PositionGreeks, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	trade, err := a.tradeService.GetTradeByID(tradeID)
+	if err != nil {
+		return nil, err
+	}
+	return services.CalculatePositionGreeks(*trade, req, time.Now())
+}
+
+// GetPortfolioGreeks sums the Greeks of every open trade at the given quotes,
+// by sector, strategy category and expiration week, with delta beta-weighted
+// to SPY
+func (a *App) GetPortfolioGreeks(req models.PortfolioGreeksRequest) (*models.PortfolioGreeks, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.portfolio == nil {
+		return nil, fmt.Errorf("portfolio service not available - database connection failed")
+	}
+	return a.portfolio.GetPortfolioGreeks(req, time.Now())
+}
+
+// RunScenario stress-tests the open trades under a grid of price moves and
+// volatility shifts plus optional sector shocks
+func (a *App) RunScenario(req models.ScenarioRequest) (*models.ScenarioResult, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.portfolio == nil {
+		return nil, fmt.Errorf("portfolio service not available - database connection failed")
+	}
+	return a.portfolio.RunScenario(req, time.Now())
+}
+
+// GetBetas returns the stored betas against SPY
+func (a *App) GetBetas() ([]models.Beta, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.betas == nil {
+		log.Printf("Beta service not initialized - database connection failed")
+		return []models.Beta{}, nil
+	}
+	return a.betas.GetBetas()
+}
+
+// SetBeta stores a ticker's beta against SPY
+func (a *App) SetBeta(ticker string, beta float64) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.betas == nil {
+		return fmt.Errorf("beta service not available - database connection failed")
+	}
+	return a.betas.SetBeta(ticker, beta)
+}
+
+// DeleteBeta removes a ticker's beta so it is weighted at 1.0
+func (a *App) DeleteBeta(ticker string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.betas == nil {
+		return fmt.Errorf("beta service not available - database connection failed")
+	}
+	return a.betas.DeleteBeta(ticker)
+}
+
+// SizePosition returns how many units of a proposed spread fit the risk
+// budget, scaled by the conviction of its sector's rating
+func (a *App) SizePosition(req models.SizingRequest) (*models.SizingResult, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.sizing == nil {
+		return nil, fmt.Errorf("sizing service not available - database connection failed")
+	}
+	return a.sizing.SizePosition(req)
+}
+
+// ParseOCCSymbol decodes a pasted OCC option symbol into its root, expiration, type and strike
+func (a *App) ParseOCCSymbol(symbol string) (occ.Symbol, error) {
+	return occ.Parse(symbol)
+}
+
+// FormatOCCSymbol encodes an option contract as a 21-character OCC symbol
+func (a *App) FormatOCCSymbol(sym occ.Symbol) (string, error) {
+	return occ.Format(sym)
+}
+
+// ============ PAYOFF API METHODS ============
+
+// CalculatePayoff returns the payoff curve, max profit/loss and breakevens for a set of legs
+func (a *App) CalculatePayoff(legs []models.Leg, opts payoff.Options) (*payoff.Analysis, error) {
+	return payoff.Analyze(legs, opts)
+}
+
+// GetTradePayoff returns the payoff analysis for a logged trade's legs
+func (a *App) GetTradePayoff(tradeID int64, opts payoff.Options) (*payoff.Analysis, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	trade, err := a.tradeService.GetTradeByID(tradeID)
+	if err != nil {
+		return nil, err
+	}
+	return payoff.Analyze(trade.Legs, opts)
+}
+
+// BuildStrategyLegs lays out a seeded strategy type around a spot price so its payoff can be previewed
+func (a *App) BuildStrategyLegs(strategy string, spec payoff.TemplateSpec) ([]models.Leg, error) {
+	return payoff.BuildStrategyLegs(strategy, spec)
+}
+
+// ============ ANALYTICS API METHODS ============
+
+// GetSentimentEdgeReport reports trade outcomes grouped by the sector rating at entry and by strategy category
+func (a *App) GetSentimentEdgeReport(startDate, endDate time.Time) (*models.SentimentEdgeReport, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.analytics == nil {
+		return nil, fmt.Errorf("analytics service not available - database connection failed")
+	}
+	return a.analytics.GetSentimentEdgeReport(startDate, endDate)
+}
+
+// ============ IMPORT API METHODS ============
+
+// GetImportBrokers returns the broker statement formats that can be imported
+func (a *App) GetImportBrokers() []string {
+	return importer.Brokers()
+}
+
+// SelectImportFile opens a file dialog for choosing a broker statement
+func (a *App) SelectImportFile() (string, error) {
+	return runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Broker Statement",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Statements (*.csv, *.xml)", Pattern: "*.csv;*.xml"},
+		},
+	})
+}
+
+// PreviewImport parses a broker statement and returns the trades and closes it would create without saving them
+func (a *App) PreviewImport(broker, path string, opts importer.Options) (*models.ImportPreview, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	return a.previewImport(broker, path, opts)
+}
+
+// ImportTrades imports a broker statement, skipping anything already imported
+func (a *App) ImportTrades(broker, path string, opts importer.Options) (*models.ImportResult, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.tradeService == nil {
+		return nil, fmt.Errorf("trade service not available - database connection failed")
+	}
+	preview, err := a.previewImport(broker, path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return a.tradeService.ApplyImport(preview)
+}
+
+// previewImport runs the broker parser over a statement file
+func (a *App) previewImport(broker, path string, opts importer.Options) (*models.ImportPreview, error) {
+	parser, err := importer.ParserFor(broker)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open statement: %w", err)
+	}
+	defer file.Close()
+
+	return importer.Preview(parser, file, a.tradeService, opts)
+}
+
+// ============ SETTINGS API METHODS ============
+
+// GetSettings returns every stored setting
+func (a *App) GetSettings() (map[string]string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.settings == nil {
+		log.Printf("Settings service not initialized - database connection failed")
+		return map[string]string{}, nil
+	}
+	return a.settings.GetSettings()
+}
+
+// SetSetting stores a setting
+func (a *App) SetSetting(key, value string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.settings == nil {
+		return fmt.Errorf("settings service not available - database connection failed")
+	}
+	return a.settings.SetSetting(key, value)
+}
+
+// ============ EXPORT API METHODS ============
+
+// ExportWorkbook saves the trades matching query, with their legs, the
+// rating history and summary analytics, to an Excel workbook chosen in a save
+// dialog. It returns nil if the dialog is cancelled.
+func (a *App) ExportWorkbook(query models.TradeQuery) (*models.WorkbookSummary, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Excel Workbook",
+		DefaultFilename: fmt.Sprintf("trades-export-%s.xlsx", time.Now().Format("2006-01-02")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Excel Workbooks (*.xlsx)", Pattern: "*.xlsx"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.workbooks == nil {
+		return nil, fmt.Errorf("export service not available - database connection failed")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workbook: %w", err)
+	}
+	defer file.Close()
+
+	summary, err := a.workbooks.ExportWorkbook(file, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write workbook: %w", err)
+	}
+	summary.Path = path
+	return summary, nil
+}
+
+// ExportBasketReport saves the basket review PDF for a date range to a file
+// chosen in a save dialog. Zero dates select the current Monday-to-Sunday
+// week; an end date is included through the end of its day. It returns nil if
+// the dialog is cancelled.
+func (a *App) ExportBasketReport(startDate, endDate time.Time) (*models.ReportSummary, error) {
+	weekStart, weekEnd := models.ReportWeek(time.Now())
+	if startDate.IsZero() {
+		startDate = weekStart
+	}
+	if endDate.IsZero() {
+		endDate = weekEnd
+	} else {
+		y, m, d := endDate.Date()
+		endDate = time.Date(y, m, d, 0, 0, 0, 0, endDate.Location()).AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Save Basket Report",
+		DefaultFilename: fmt.Sprintf("basket-report-%s.pdf", endDate.Format("2006-01-02")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "PDF Documents (*.pdf)", Pattern: "*.pdf"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.reports == nil {
+		return nil, fmt.Errorf("report service not available - database connection failed")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+	defer file.Close()
+
+	summary, err := a.reports.WriteBasketReport(file, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+	summary.Path = path
+	return summary, nil
+}
+
+// ============ ARCHIVE API METHODS ============
+
+// ExportArchive saves the whole workspace to a JSON archive chosen in a save
+// dialog. It returns nil if the dialog is cancelled.
+func (a *App) ExportArchive() (*models.ArchiveSummary, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Archive",
+		DefaultFilename: fmt.Sprintf("trading-dashboard-%s.json", time.Now().Format("2006-01-02")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Archives (*.json)", Pattern: "*.json"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.archives == nil {
+		return nil, fmt.Errorf("archive service not available - database connection failed")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer file.Close()
+
+	summary, err := a.archives.ExportArchive(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	summary.Path = path
+	return summary, nil
+}
+
+// SelectArchiveFile opens a file dialog for choosing an archive to import
+func (a *App) SelectArchiveFile() (string, error) {
+	return runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Archive",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Archives (*.json)", Pattern: "*.json"},
+		},
+	})
+}
+
+// ImportArchive loads an archive in merge or replace mode. The database is
+// backed up first, since a replace deletes everything not in the archive.
+func (a *App) ImportArchive(path, mode string) (*models.ArchiveImportResult, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.archives == nil {
+		return nil, fmt.Errorf("archive service not available - database connection failed")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := a.backups.CreateBackup(models.BackupReasonPreImport); err != nil {
+		return nil, fmt.Errorf("failed to back up database before import: %w", err)
+	}
+	return a.archives.ImportArchive(file, mode)
+}
+
+// ============ ALERT API METHODS ============
+
+// GetAlerts returns the alert inbox newest first, optionally only unread
+// alerts; a positive limit caps how many are returned
+func (a *App) GetAlerts(unreadOnly bool, limit int) ([]models.Alert, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		log.Printf("Alert service not initialized - database connection failed")
+		return []models.Alert{}, nil
+	}
+	return a.alerts.GetAlerts(unreadOnly, limit)
+}
+
+// GetUnreadAlertCount returns the number of unread alerts
+func (a *App) GetUnreadAlertCount() (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return 0, nil
+	}
+	return a.alerts.GetUnreadCount()
+}
+
+// MarkAlertRead marks an alert as read
+func (a *App) MarkAlertRead(id int64) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return fmt.Errorf("alert service not available - database connection failed")
+	}
+	return a.alerts.MarkRead(id)
+}
+
+// MarkAllAlertsRead marks every unread alert as read
+func (a *App) MarkAllAlertsRead() (int64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return 0, fmt.Errorf("alert service not available - database connection failed")
+	}
+	return a.alerts.MarkAllRead()
+}
+
+// CheckAlertsNow checks the alert rules without waiting for the scheduler
+// and notifies the frontend of any new alerts
+func (a *App) CheckAlertsNow() ([]models.Alert, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return nil, fmt.Errorf("alert service not available - database connection failed")
+	}
+	alerts, err := a.alerts.CheckAlerts(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) > 0 {
+		a.notifyAlerts(alerts)
+	}
+	return alerts, nil
+}
+
+// GetAlertRules returns the alert rules, with defaults for unsaved rules
+func (a *App) GetAlertRules() (models.AlertRules, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return models.AlertRules{}, fmt.Errorf("alert service not available - database connection failed")
+	}
+	return a.alerts.GetAlertRules()
+}
+
+// SaveAlertRules validates and stores the alert rules
+func (a *App) SaveAlertRules(rules models.AlertRules) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return fmt.Errorf("alert service not available - database connection failed")
+	}
+	return a.alerts.SaveAlertRules(rules)
+}
+
+// GetUnderlyingPrices returns the stored underlying prices checked against
+// targets and stops
+func (a *App) GetUnderlyingPrices() ([]models.UnderlyingPrice, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		log.Printf("Alert service not initialized - database connection failed")
+		return []models.UnderlyingPrice{}, nil
+	}
+	return a.alerts.GetPrices()
+}
+
+// SetUnderlyingPrice stores the latest price of an underlying
+func (a *App) SetUnderlyingPrice(ticker string, price float64) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return fmt.Errorf("alert service not available - database connection failed")
+	}
+	return a.alerts.SetPrice(ticker, price)
+}
+
+// DeleteUnderlyingPrice removes an underlying's price
+func (a *App) DeleteUnderlyingPrice(ticker string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.alerts == nil {
+		return fmt.Errorf("alert service not available - database connection failed")
+	}
+	return a.alerts.DeletePrice(ticker)
+}
+
+// ============ BACKUP API METHODS ============
+
+// CreateBackup takes a manual backup of the database
+func (a *App) CreateBackup() (*models.BackupInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.backups == nil {
+		return nil, fmt.Errorf("backup service not available - database connection failed")
+	}
+	return a.backups.CreateBackup(models.BackupReasonManual)
+}
+
+// ListBackups returns the retained database backups, newest first
+func (a *App) ListBackups() ([]models.BackupInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.backups == nil {
+		log.Printf("Backup service not initialized - database connection failed")
+		return []models.BackupInfo{}, nil
+	}
+	return a.backups.ListBackups()
+}
+
+// RestoreBackup replaces the database with a backup and reopens it. The
+// database as it was is saved as a pre-restore backup first, and is put back
+// if the restored file cannot be opened. It returns that pre-restore backup.
+func (a *App) RestoreBackup(name string) (*models.BackupInfo, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.backups == nil {
+		return nil, fmt.Errorf("backup service not available - database connection failed")
+	}
+
+	a.stopBackground(a.ctx)
+	safety, err := a.backups.RestoreBackup(name, a.dbPath)
+	if safety == nil {
+		// The backup was rejected before the database was closed
+		a.startBackground(false)
+		return nil, err
+	}
+
+	if err == nil {
+		if err = a.openDatabase(); err == nil {
+			a.startBackground(false)
+			log.Printf("Restored database from backup %s", name)
+			runtime.EventsEmit(a.ctx, eventDatabaseRestored, name)
+			return safety, nil
+		}
+		log.Printf("Restored backup %s could not be opened, rolling back: %v", name, err)
+		if rollbackErr := database.ReplaceDatabaseFile(a.dbPath, safety.Path); rollbackErr != nil {
+			return nil, fmt.Errorf("failed to open restored backup: %w (rolling back also failed: %v)", err, rollbackErr)
+		}
+	}
+
+	// The database file is the one from before the restore
+	if openErr := a.openDatabase(); openErr != nil {
+		return nil, fmt.Errorf("failed to restore backup: %w (reopening the previous database also failed: %v)", err, openErr)
+	}
+	a.startBackground(false)
+	return nil, fmt.Errorf("failed to restore backup: %w", err)
+}
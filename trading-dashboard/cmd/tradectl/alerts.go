@@ -0,0 +1,227 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+func (e *env) alertsCheck(args []string) error {
+	fs := flag.NewFlagSet("alerts check", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	alerts, err := e.alerts.CheckAlerts(time.Now())
+	if err != nil {
+		return err
+	}
+	return output(*format, alerts, func() {
+		fmt.Printf("%d new alert(s)\n", len(alerts))
+		for _, a := range alerts {
+			fmt.Printf("  #%d [%s] %s\n", a.ID, a.Rule, a.Message)
+		}
+	})
+}
+
+func (e *env) alertsList(args []string) error {
+	fs := flag.NewFlagSet("alerts list", flag.ContinueOnError)
+	unread := fs.Bool("unread", false, "only unread alerts")
+	limit := fs.Int("limit", 50, "maximum number of alerts, 0 for all")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	alerts, err := e.alerts.GetAlerts(*unread, *limit)
+	if err != nil {
+		return err
+	}
+	return output(*format, alerts, func() {
+		rows := make([][]string, 0, len(alerts))
+		for _, a := range alerts {
+			read := ""
+			if a.ReadAt == nil {
+				read = "*"
+			}
+			rows = append(rows, []string{
+				strconv.FormatInt(a.ID, 10),
+				read,
+				a.CreatedAt.Local().Format("2006-01-02 15:04"),
+				a.Rule,
+				a.Message,
+			})
+		}
+		printTable([]string{"ID", "NEW", "CREATED", "RULE", "MESSAGE"}, rows)
+	})
+}
+
+func (e *env) alertsRead(args []string) error {
+	fs := flag.NewFlagSet("alerts read", flag.ContinueOnError)
+	all := fs.Bool("all", false, "mark every unread alert as read")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *all {
+		if len(positional) != 0 {
+			return fmt.Errorf("usage: tradectl alerts read <id> | --all")
+		}
+		n, err := e.alerts.MarkAllRead()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Marked %d alert(s) read\n", n)
+		return nil
+	}
+
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl alerts read <id> | --all")
+	}
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid alert id: %q", positional[0])
+	}
+	if err := e.alerts.MarkRead(id); err != nil {
+		return err
+	}
+	fmt.Printf("Alert #%d marked read\n", id)
+	return nil
+}
+
+// onOff parses an "on"/"off" flag value
+func onOff(name, v string) (bool, error) {
+	switch v {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("--%s: must be on or off, got %q", name, v)
+	}
+}
+
+func (e *env) alertsConfig(args []string) error {
+	fs := flag.NewFlagSet("alerts config", flag.ContinueOnError)
+	targetStop := fs.String("target-stop", "", "underlying reaching a trade's target or stop: on or off")
+	expiring := fs.String("expiring", "", "days to expiration to alert at, or off")
+	ratingSign := fs.String("rating-sign", "", "sector rating changing sign: on or off")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	rules, err := e.alerts.GetAlertRules()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if *targetStop != "" {
+		if rules.TargetStop.Enabled, err = onOff("target-stop", *targetStop); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if *expiring != "" {
+		if *expiring == "off" {
+			rules.Expiring.Enabled = false
+		} else {
+			days, err := strconv.Atoi(*expiring)
+			if err != nil {
+				return fmt.Errorf("--expiring: must be a number of days or off, got %q", *expiring)
+			}
+			rules.Expiring = models.ExpiringAlertRule{Enabled: true, Days: days}
+		}
+		changed = true
+	}
+	if *ratingSign != "" {
+		if rules.RatingSign.Enabled, err = onOff("rating-sign", *ratingSign); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if changed {
+		if err := e.alerts.SaveAlertRules(rules); err != nil {
+			return err
+		}
+	}
+
+	return output(*format, rules, func() {
+		state := func(on bool) string {
+			if on {
+				return "on"
+			}
+			return "off"
+		}
+		expiringState := "off"
+		if rules.Expiring.Enabled {
+			expiringState = fmt.Sprintf("%d day(s) or less", rules.Expiring.Days)
+		}
+		fmt.Printf("Target/stop:  %s\n", state(rules.TargetStop.Enabled))
+		fmt.Printf("Expiring:     %s\n", expiringState)
+		fmt.Printf("Rating sign:  %s\n", state(rules.RatingSign.Enabled))
+	})
+}
+
+func (e *env) priceList(args []string) error {
+	fs := flag.NewFlagSet("price list", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	prices, err := e.alerts.GetPrices()
+	if err != nil {
+		return err
+	}
+	return output(*format, prices, func() {
+		rows := make([][]string, 0, len(prices))
+		for _, p := range prices {
+			rows = append(rows, []string{p.Ticker, strconv.FormatFloat(p.Price, 'f', -1, 64), p.UpdatedAt.Local().Format("2006-01-02 15:04")})
+		}
+		printTable([]string{"TICKER", "PRICE", "UPDATED"}, rows)
+	})
+}
+
+func (e *env) priceSet(args []string) error {
+	fs := flag.NewFlagSet("price set", flag.ContinueOnError)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("usage: tradectl price set <ticker> <price>")
+	}
+	price, err := strconv.ParseFloat(positional[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid price: %q", positional[1])
+	}
+	if err := e.alerts.SetPrice(positional[0], price); err != nil {
+		return err
+	}
+	fmt.Printf("Price of %s set to %g\n", strings.ToUpper(positional[0]), price)
+	return nil
+}
+
+func (e *env) priceDelete(args []string) error {
+	fs := flag.NewFlagSet("price delete", flag.ContinueOnError)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl price delete <ticker>")
+	}
+	if err := e.alerts.DeletePrice(positional[0]); err != nil {
+		return err
+	}
+	fmt.Printf("Price of %s removed\n", strings.ToUpper(positional[0]))
+	return nil
+}
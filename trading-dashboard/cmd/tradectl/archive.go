@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"trading-dashboard/pkg/models"
+)
+
+func (e *env) archiveExport(args []string) error {
+	fs := flag.NewFlagSet("archive export", flag.ContinueOnError)
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl archive export <file>")
+	}
+
+	file, err := os.Create(positional[0])
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer file.Close()
+
+	summary, err := e.archives.ExportArchive(file)
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	summary.Path = positional[0]
+
+	return output(*format, summary, func() {
+		c := summary.Counts
+		fmt.Printf("Exported %d trades, %d market ratings, %d strategy types, %d settings, %d betas, %d prices and %d alerts to %s\n",
+			c.Trades, c.MarketRatings, c.StrategyTypes, c.Settings, c.Betas, c.UnderlyingPrices, c.Alerts, summary.Path)
+	})
+}
+
+func (e *env) archiveImport(args []string) error {
+	fs := flag.NewFlagSet("archive import", flag.ContinueOnError)
+	mode := fs.String("mode", models.ArchiveModeMerge, "merge into the existing data or replace it: merge|replace")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl archive import <file> [--mode merge|replace]")
+	}
+
+	file, err := os.Open(positional[0])
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := e.backups.CreateBackup(models.BackupReasonPreImport); err != nil {
+		return fmt.Errorf("failed to back up database before import: %w", err)
+	}
+	result, err := e.archives.ImportArchive(file, *mode)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, result, func() {
+		in, skip := result.Imported, result.Skipped
+		fmt.Printf("Imported %d trades, %d market ratings, %d strategy types, %d settings, %d betas, %d prices and %d alerts (%s)\n",
+			in.Trades, in.MarketRatings, in.StrategyTypes, in.Settings, in.Betas, in.UnderlyingPrices, in.Alerts, result.Mode)
+		if skip != (models.ArchiveCounts{}) {
+			fmt.Printf("Skipped as already present: %d trades, %d market ratings, %d strategy types, %d settings, %d betas, %d prices, %d alerts\n",
+				skip.Trades, skip.MarketRatings, skip.StrategyTypes, skip.Settings, skip.Betas, skip.UnderlyingPrices, skip.Alerts)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"trading-dashboard/pkg/models"
+)
+
+func (e *env) backupCreate(args []string) error {
+	fs := flag.NewFlagSet("backup create", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	info, err := e.backups.CreateBackup(models.BackupReasonManual)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, info, func() {
+		fmt.Printf("Backed up %s to %s (%d bytes)\n", e.dbPath, info.Path, info.Size)
+	})
+}
+
+func (e *env) backupList(args []string) error {
+	fs := flag.NewFlagSet("backup list", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	backups, err := e.backups.ListBackups()
+	if err != nil {
+		return err
+	}
+
+	return output(*format, backups, func() {
+		rows := make([][]string, 0, len(backups))
+		for _, b := range backups {
+			rows = append(rows, []string{
+				b.Name,
+				b.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+				b.Reason,
+				strconv.FormatInt(b.Size, 10),
+			})
+		}
+		printTable([]string{"NAME", "CREATED", "REASON", "BYTES"}, rows)
+	})
+}
+
+func (e *env) backupRestore(args []string) error {
+	fs := flag.NewFlagSet("backup restore", flag.ContinueOnError)
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl backup restore <name>")
+	}
+
+	// The database is closed by the restore and migrated on its next open
+	safety, err := e.backups.RestoreBackup(positional[0], e.dbPath)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, safety, func() {
+		fmt.Printf("Restored %s from %s; the previous database was saved as %s\n", e.dbPath, positional[0], safety.Name)
+	})
+}
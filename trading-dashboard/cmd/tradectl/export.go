@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+func (e *env) exportXLSX(args []string) error {
+	fs := flag.NewFlagSet("export xlsx", flag.ContinueOnError)
+	from := fs.String("from", "", "first entry date (default: no limit)")
+	to := fs.String("to", "", "last entry date (default: no limit)")
+	status := fs.String("status", "", "only trades with this status")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl export xlsx <file> [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--status S]")
+	}
+
+	query := models.TradeQuery{}
+	if query.EntryFrom, err = parseDate("from", *from, time.Time{}); err != nil {
+		return err
+	}
+	if query.EntryTo, err = parseDate("to", *to, time.Time{}); err != nil {
+		return err
+	}
+	if !query.EntryTo.IsZero() {
+		query.EntryTo = query.EntryTo.Add(24*time.Hour - time.Nanosecond)
+	}
+	if *status != "" {
+		query.Statuses = []string{*status}
+	}
+
+	file, err := os.Create(positional[0])
+	if err != nil {
+		return fmt.Errorf("failed to create workbook: %w", err)
+	}
+	defer file.Close()
+
+	summary, err := e.workbooks.ExportWorkbook(file, query)
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	summary.Path = positional[0]
+
+	return output(*format, summary, func() {
+		fmt.Printf("Exported %d trades, %d legs and %d market ratings to %s\n",
+			summary.Trades, summary.Legs, summary.Ratings, summary.Path)
+	})
+}
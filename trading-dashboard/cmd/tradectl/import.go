@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"trading-dashboard/pkg/importer"
+	"trading-dashboard/pkg/models"
+)
+
+func (e *env) tradesImport(args []string) error {
+	fs := flag.NewFlagSet("trades import", flag.ContinueOnError)
+	broker := fs.String("broker", "", "statement format: thinkorswim, ibkr or tastytrade")
+	sector := fs.String("sector", "", "sector for tickers never traded before (default "+importer.DefaultSector+")")
+	commit := fs.Bool("commit", false, "write the trades; without it only a preview is shown")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || *broker == "" {
+		return fmt.Errorf("usage: tradectl trades import <file> --broker thinkorswim|ibkr|tastytrade [--sector S] [--commit]")
+	}
+
+	parser, err := importer.ParserFor(*broker)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	preview, err := importer.Preview(parser, file, e.trades, importer.Options{DefaultSector: *sector})
+	if err != nil {
+		return err
+	}
+
+	if !*commit {
+		return output(*format, preview, func() { printImportPreview(preview) })
+	}
+
+	result, err := e.trades.ApplyImport(preview)
+	if err != nil {
+		return err
+	}
+	return output(*format, result, func() {
+		fmt.Printf("Created %d trade(s), recorded %d fill(s), closed %d trade(s), skipped %d duplicate(s)\n",
+			len(result.CreatedTrades), result.RecordedFills, len(result.ClosedTrades), result.SkippedDuplicates)
+		for _, w := range result.Warnings {
+			fmt.Println("warning:", w)
+		}
+	})
+}
+
+// printImportPreview renders the planned trades and closes of a dry run
+func printImportPreview(p *models.ImportPreview) {
+	rows := make([][]string, 0, len(p.Trades))
+	for _, t := range p.Trades {
+		rows = append(rows, []string{
+			t.ExternalID,
+			t.Request.Ticker,
+			t.Request.Sector,
+			t.Request.StrategyType,
+			t.Request.EntryDate.Format(dateLayout),
+			t.Request.ExpirationDate.Format(dateLayout),
+			strconv.Itoa(len(t.Request.Legs)),
+			fillSummary(t.OpenFill),
+			importState(t.Duplicate, t.Warnings),
+		})
+	}
+	printTable([]string{"ORDER", "TICKER", "SECTOR", "STRATEGY", "ENTRY", "EXPIRATION", "LEGS", "FILL", "STATE"}, rows)
+
+	if len(p.Closes) > 0 {
+		fmt.Println()
+		rows = rows[:0]
+		for _, c := range p.Closes {
+			target := c.TradeExternalID
+			if c.TradeID != 0 {
+				target = "#" + strconv.FormatInt(c.TradeID, 10)
+			}
+			rows = append(rows, []string{
+				c.ExternalID,
+				c.Ticker,
+				target,
+				c.Fill.FilledAt.Format(dateLayout),
+				fillSummary(c.Fill),
+				importState(c.Duplicate, c.Warnings),
+			})
+		}
+		printTable([]string{"CLOSE", "TICKER", "TRADE", "DATE", "FILL", "STATE"}, rows)
+	}
+
+	for _, t := range p.Trades {
+		for _, w := range t.Warnings {
+			fmt.Printf("warning: %s: %s\n", t.ExternalID, w)
+		}
+	}
+	for _, c := range p.Closes {
+		for _, w := range c.Warnings {
+			fmt.Printf("warning: %s: %s\n", c.ExternalID, w)
+		}
+	}
+	for _, s := range p.Skipped {
+		fmt.Println("skipped:", s)
+	}
+	fmt.Printf("\nDry run: %d trade(s), %d close(s), %d duplicate(s). Re-run with --commit to import.\n",
+		len(p.Trades), len(p.Closes), p.Duplicates)
+}
+
+// fillSummary formats a fill as "2 x 1.25 sell"
+func fillSummary(f models.FillRequest) string {
+	return fmt.Sprintf("%d x %.2f %s", f.Quantity, f.Price, f.Side)
+}
+
+func importState(duplicate bool, warnings []string) string {
+	switch {
+	case duplicate:
+		return "duplicate"
+	case len(warnings) > 0:
+		return "review"
+	default:
+		return "new"
+	}
+}
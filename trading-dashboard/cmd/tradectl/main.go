@@ -0,0 +1,240 @@
+// Command tradectl manages trades and market ratings from the terminal,
+// sharing the SQLite database used by the desktop app.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"trading-dashboard/pkg/database"
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/services"
+)
+
+const usage = `Usage: tradectl [-db path] <command> <subcommand> [flags]
+
+Commands:
+  trades list    [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--status planned|active|adjusted|rolled|assigned|exercised|closed|expired]
+                 [--ticker T --sector S --strategy NAME] [--sort KEY [--desc]] [--limit N] [--cursor C]
+  trades search <query> [--status S --strategy NAME --sector S --limit N]
+  trades add     --ticker T --sector S --strategy NAME --expiration YYYY-MM-DD [--planned] [--check] [--leg side:type:strike:qty:premium[:YYYY-MM-DD]]...
+                 (a leg may also be given as side:OCC-SYMBOL:qty:premium, e.g. "sell:SPY   250815P00600000:1:2.10")
+  trades close   <id> [--price P --side buy|sell --fees F --qty N]
+  trades status  <id> <status> [--reason TEXT]
+  trades roll    <id> --expiration YYYY-MM-DD --leg ...  [--close-price P --close-side buy|sell] [--open-price P --open-side sell|buy --qty N]
+  trades chain   <id> [--mark P]
+  trades expire
+  trades import  <file> --broker thinkorswim|ibkr|tastytrade [--sector S] [--commit]
+  rules show
+  rules set      [--max-open severity:N] [--rating-direction severity:T] [--dte severity:MIN-MAX] [--duplicate-week severity]
+                 (severity is block, warn or off)
+  rating set     --overall N [--sector "Name=N"]...
+  rating latest
+  portfolio greeks --quote "TICKER=price:iv"... [--spy P] [--rate R]
+  portfolio stress --quote "TICKER=price:iv"... [--moves -20,0,20] [--vols -10,0,10] [--shock "Sector=move:iv"]... [--days N]
+  size trade     --sector S --leg side:type:strike:qty:premium... [--direction bullish|bearish|neutral] [--equity E] [--risk PCT]
+  size config    [--equity E] [--risk PCT]
+  alerts check
+  alerts list    [--unread] [--limit N]
+  alerts read    <id> | --all
+  alerts config  [--target-stop on|off] [--expiring DAYS|off] [--rating-sign on|off]
+  price list
+  price set      <ticker> <price>
+  price delete   <ticker>
+  beta list
+  beta set       <ticker> <beta>
+  beta delete    <ticker>
+  report basket  <file.pdf> [--from YYYY-MM-DD] [--to YYYY-MM-DD]
+  export xlsx    <file> [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--status S]
+  archive export <file>
+  archive import <file> [--mode merge|replace]
+  backup create
+  backup list
+  backup restore <name>
+
+Every subcommand accepts --format table|json.
+The database defaults to $TRADING_DASHBOARD_DB, then the desktop app's data directory.
+Backups are kept in a backups directory next to the database.
+`
+
+// env holds the services shared by all subcommands
+type env struct {
+	dbPath    string
+	market    *services.MarketService
+	trades    *services.TradeService
+	archives  *services.ArchiveService
+	backups   *services.BackupService
+	workbooks *services.WorkbookService
+	reports   *services.ReportService
+	portfolio *services.PortfolioService
+	betas     *services.BetaService
+	sizing    *services.SizingService
+	settings  *services.SettingsService
+	alerts    *services.AlertService
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "tradectl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("tradectl", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	dbPath := fs.String("db", "", "path to trading_dashboard.db")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rest := fs.Args()
+	if len(rest) < 2 {
+		fs.Usage()
+		return fmt.Errorf("a command and subcommand are required")
+	}
+
+	path, err := resolveDBPath(*dbPath)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.NewDB(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := db.Migrate(); err != nil {
+		return fmt.Errorf("failed to prepare database %s: %w", path, err)
+	}
+	if _, err := db.EnsureSearchIndex(); err != nil {
+		return fmt.Errorf("failed to prepare database %s: %w", path, err)
+	}
+	if err := db.SeedStrategyTypes(); err != nil {
+		return fmt.Errorf("failed to prepare database %s: %w", path, err)
+	}
+
+	e := &env{
+		dbPath:    path,
+		market:    services.NewMarketService(db.DB),
+		trades:    services.NewTradeService(db.DB),
+		archives:  services.NewArchiveService(db.DB),
+		backups:   services.NewBackupService(db, filepath.Join(filepath.Dir(path), "backups"), models.DefaultBackupRetention),
+		workbooks: services.NewWorkbookService(db.DB),
+		reports:   services.NewReportService(db.DB),
+		portfolio: services.NewPortfolioService(db.DB),
+		betas:     services.NewBetaService(db.DB),
+		sizing:    services.NewSizingService(db.DB),
+		settings:  services.NewSettingsService(db.DB),
+		alerts:    services.NewAlertService(db.DB),
+	}
+
+	cmd, sub, subArgs := rest[0], rest[1], rest[2:]
+	switch cmd + " " + sub {
+	case "trades list":
+		return e.tradesList(subArgs)
+	case "trades search":
+		return e.tradesSearch(subArgs)
+	case "trades add":
+		return e.tradesAdd(subArgs)
+	case "trades close":
+		return e.tradesClose(subArgs)
+	case "trades status":
+		return e.tradesStatus(subArgs)
+	case "trades roll":
+		return e.tradesRoll(subArgs)
+	case "trades chain":
+		return e.tradesChain(subArgs)
+	case "trades expire":
+		return e.tradesExpire(subArgs)
+	case "trades import":
+		return e.tradesImport(subArgs)
+	case "rating set":
+		return e.ratingSet(subArgs)
+	case "rating latest":
+		return e.ratingLatest(subArgs)
+	case "portfolio greeks":
+		return e.portfolioGreeks(subArgs)
+	case "portfolio stress":
+		return e.portfolioStress(subArgs)
+	case "rules show":
+		return e.rulesShow(subArgs)
+	case "rules set":
+		return e.rulesSet(subArgs)
+	case "size trade":
+		return e.sizeTrade(subArgs)
+	case "size config":
+		return e.sizeConfig(subArgs)
+	case "alerts check":
+		return e.alertsCheck(subArgs)
+	case "alerts list":
+		return e.alertsList(subArgs)
+	case "alerts read":
+		return e.alertsRead(subArgs)
+	case "alerts config":
+		return e.alertsConfig(subArgs)
+	case "price list":
+		return e.priceList(subArgs)
+	case "price set":
+		return e.priceSet(subArgs)
+	case "price delete":
+		return e.priceDelete(subArgs)
+	case "beta list":
+		return e.betaList(subArgs)
+	case "beta set":
+		return e.betaSet(subArgs)
+	case "beta delete":
+		return e.betaDelete(subArgs)
+	case "report basket":
+		return e.reportBasket(subArgs)
+	case "export xlsx":
+		return e.exportXLSX(subArgs)
+	case "archive export":
+		return e.archiveExport(subArgs)
+	case "archive import":
+		return e.archiveImport(subArgs)
+	case "backup create":
+		return e.backupCreate(subArgs)
+	case "backup list":
+		return e.backupList(subArgs)
+	case "backup restore":
+		return e.backupRestore(subArgs)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown command: %s %s", cmd, sub)
+	}
+}
+
+// resolveDBPath picks the database file: the -db flag, then
+// $TRADING_DASHBOARD_DB, then the locations the desktop app uses
+func resolveDBPath(flagPath string) (string, error) {
+	if flagPath != "" {
+		return flagPath, nil
+	}
+	if envPath := os.Getenv("TRADING_DASHBOARD_DB"); envPath != "" {
+		return envPath, nil
+	}
+
+	const dbFile = "trading_dashboard.db"
+
+	// Portable installs keep data next to the executable
+	if exePath, err := os.Executable(); err == nil {
+		candidate := filepath.Join(filepath.Dir(exePath), "data", dbFile)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	if appData := os.Getenv("APPDATA"); appData != "" {
+		return filepath.Join(appData, "TradingDashboard", dbFile), nil
+	}
+
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, "TradingDashboard", dbFile), nil
+	}
+
+	return "", fmt.Errorf("unable to determine database path; pass -db")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// formatFlag registers the --format flag on a subcommand
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", "table", "output format: table or json")
+}
+
+// parseFlags parses flags that may appear before or after positional
+// arguments and returns the positional arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// printJSON writes v as indented JSON
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable writes rows as aligned columns under the header
+func printTable(header []string, rows [][]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+// output prints v as JSON or renders it with the table function
+func output(format string, v any, table func()) error {
+	switch format {
+	case "json":
+		return printJSON(v)
+	case "table", "":
+		table()
+		return nil
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
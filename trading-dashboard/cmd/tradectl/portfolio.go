@@ -0,0 +1,253 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// quoteFlag collects repeated --quote "TICKER=price:iv" values
+type quoteFlag map[string]models.Quote
+
+func (f quoteFlag) String() string { return "" }
+
+func (f quoteFlag) Set(v string) error {
+	ticker, value, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("expected TICKER=price:iv, got %q", v)
+	}
+	priceText, ivText, _ := strings.Cut(value, ":")
+	price, err := strconv.ParseFloat(strings.TrimSpace(priceText), 64)
+	if err != nil {
+		return fmt.Errorf("invalid price for %s: %q", ticker, priceText)
+	}
+	var iv float64
+	if ivText != "" {
+		if iv, err = strconv.ParseFloat(strings.TrimSpace(ivText), 64); err != nil {
+			return fmt.Errorf("invalid implied volatility for %s: %q", ticker, ivText)
+		}
+	}
+	f[strings.ToUpper(strings.TrimSpace(ticker))] = models.Quote{Price: price, Volatility: iv}
+	return nil
+}
+
+func (e *env) portfolioGreeks(args []string) error {
+	fs := flag.NewFlagSet("portfolio greeks", flag.ContinueOnError)
+	quotes := quoteFlag{}
+	fs.Var(quotes, "quote", `underlying price and implied volatility as "TICKER=price:iv", e.g. "AAPL=190:0.28" (repeatable)`)
+	spy := fs.Float64("spy", 0, "SPY price (default: the SPY --quote)")
+	rate := fs.Float64("rate", 0.045, "risk-free rate as a decimal")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	result, err := e.portfolio.GetPortfolioGreeks(models.PortfolioGreeksRequest{
+		Quotes:         quotes,
+		Rate:           *rate,
+		BenchmarkPrice: *spy,
+	}, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return output(*format, result, func() {
+		header := []string{"", "TRADES", "DELTA", "GAMMA", "THETA", "VEGA", "SPY DELTA", "RATING", "BIAS"}
+		row := func(b models.GreeksBucket) []string {
+			rating := ""
+			if b.Rating != nil {
+				rating = fmt.Sprintf("%+g", *b.Rating)
+			}
+			return []string{b.Label, strconv.Itoa(b.Trades), fmt.Sprintf("%.1f", b.Delta), fmt.Sprintf("%.2f", b.Gamma),
+				fmt.Sprintf("%.2f", b.Theta), fmt.Sprintf("%.2f", b.Vega), fmt.Sprintf("%.1f", b.BetaWeightedDelta), rating, b.Bias}
+		}
+		section := func(title string, buckets []models.GreeksBucket) {
+			rows := make([][]string, 0, len(buckets))
+			for _, b := range buckets {
+				rows = append(rows, row(b))
+			}
+			header[0] = title
+			fmt.Println()
+			printTable(header, rows)
+		}
+
+		header[0] = "PORTFOLIO"
+		printTable(header, [][]string{row(result.Total)})
+		section("SECTOR", result.BySector)
+		section("CATEGORY", result.ByCategory)
+		section("EXPIRATION WEEK", result.ByExpiration)
+
+		if len(result.Unpriced) > 0 {
+			fmt.Printf("\nLeft out, no --quote: %s\n", strings.Join(result.Unpriced, ", "))
+		}
+		if len(result.DefaultBeta) > 0 {
+			fmt.Printf("Weighted at beta 1.0, none stored: %s\n", strings.Join(result.DefaultBeta, ", "))
+		}
+	})
+}
+
+func (e *env) betaList(args []string) error {
+	fs := flag.NewFlagSet("beta list", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	betas, err := e.betas.GetBetas()
+	if err != nil {
+		return err
+	}
+	return output(*format, betas, func() {
+		rows := make([][]string, 0, len(betas))
+		for _, b := range betas {
+			rows = append(rows, []string{b.Ticker, strconv.FormatFloat(b.Beta, 'f', -1, 64), b.UpdatedAt.Local().Format("2006-01-02")})
+		}
+		printTable([]string{"TICKER", "BETA", "UPDATED"}, rows)
+	})
+}
+
+func (e *env) betaSet(args []string) error {
+	fs := flag.NewFlagSet("beta set", flag.ContinueOnError)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("usage: tradectl beta set <ticker> <beta>")
+	}
+	beta, err := strconv.ParseFloat(positional[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid beta: %q", positional[1])
+	}
+	if err := e.betas.SetBeta(positional[0], beta); err != nil {
+		return err
+	}
+	fmt.Printf("Beta of %s set to %g\n", strings.ToUpper(positional[0]), beta)
+	return nil
+}
+
+func (e *env) betaDelete(args []string) error {
+	fs := flag.NewFlagSet("beta delete", flag.ContinueOnError)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl beta delete <ticker>")
+	}
+	if err := e.betas.DeleteBeta(positional[0]); err != nil {
+		return err
+	}
+	fmt.Printf("Beta of %s removed\n", strings.ToUpper(positional[0]))
+	return nil
+}
+
+// percentsFlag parses a comma-separated list of percentages into decimals
+type percentsFlag []float64
+
+func (f *percentsFlag) String() string { return "" }
+
+func (f *percentsFlag) Set(v string) error {
+	*f = nil
+	for _, part := range strings.Split(v, ",") {
+		pct, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return fmt.Errorf("invalid percentage: %q", part)
+		}
+		*f = append(*f, pct/100)
+	}
+	return nil
+}
+
+// shockFlag collects repeated --shock "Sector=move[:iv]" values in percent
+type shockFlag []models.SectorShock
+
+func (f *shockFlag) String() string { return "" }
+
+func (f *shockFlag) Set(v string) error {
+	sector, value, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("expected Sector=move:iv, got %q", v)
+	}
+	moveText, volText, _ := strings.Cut(value, ":")
+	move, err := strconv.ParseFloat(strings.TrimSpace(moveText), 64)
+	if err != nil {
+		return fmt.Errorf("invalid price move for %s: %q", sector, moveText)
+	}
+	var vol float64
+	if volText != "" {
+		if vol, err = strconv.ParseFloat(strings.TrimSpace(volText), 64); err != nil {
+			return fmt.Errorf("invalid volatility shift for %s: %q", sector, volText)
+		}
+	}
+	*f = append(*f, models.SectorShock{Sector: strings.TrimSpace(sector), PriceMove: move / 100, VolShift: vol / 100})
+	return nil
+}
+
+func (e *env) portfolioStress(args []string) error {
+	fs := flag.NewFlagSet("portfolio stress", flag.ContinueOnError)
+	quotes := quoteFlag{}
+	fs.Var(quotes, "quote", `underlying price and implied volatility as "TICKER=price:iv", e.g. "AAPL=190:0.28" (repeatable)`)
+	var moves, vols percentsFlag
+	fs.Var(&moves, "moves", "underlying moves in percent, e.g. -20,-10,0,10,20 (default -20,-10,-5,0,5,10,20)")
+	fs.Var(&vols, "vols", "implied volatility shifts in points, e.g. -10,0,10 (default -10,0,10,20)")
+	var shocks shockFlag
+	fs.Var(&shocks, "shock", `extra sector move and IV shift in percent as "Sector=move:iv", e.g. "Financial Services=-30:25" (repeatable)`)
+	days := fs.Int("days", 0, "days forward to value the trades")
+	rate := fs.Float64("rate", 0.045, "risk-free rate as a decimal")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	result, err := e.portfolio.RunScenario(models.ScenarioRequest{
+		Quotes:       quotes,
+		Rate:         *rate,
+		PriceMoves:   moves,
+		VolShifts:    vols,
+		DaysForward:  *days,
+		SectorShocks: shocks,
+	}, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return output(*format, result, func() {
+		header := []string{"MOVE \\ IV"}
+		for _, shift := range result.VolShifts {
+			header = append(header, fmt.Sprintf("%+g", shift*100))
+		}
+		rows := make([][]string, 0, len(result.PriceMoves))
+		for i, move := range result.PriceMoves {
+			row := []string{fmt.Sprintf("%+g%%", move*100)}
+			for _, pnl := range result.Total.PnL[i] {
+				row = append(row, fmt.Sprintf("%.0f", pnl))
+			}
+			rows = append(rows, row)
+		}
+		fmt.Printf("P&L of %d trades valued %s\n", result.Total.Trades, result.ValuedAt.Local().Format(dateLayout))
+		for _, shock := range result.SectorShocks {
+			fmt.Printf("  %s shocked %+g%%, IV %+g\n", shock.Sector, shock.PriceMove*100, shock.VolShift*100)
+		}
+		printTable(header, rows)
+
+		sectorRows := make([][]string, 0, len(result.BySector))
+		for _, grid := range result.BySector {
+			sectorRows = append(sectorRows, []string{grid.Label, strconv.Itoa(grid.Trades), fmt.Sprintf("%.0f", grid.WorstPnL),
+				fmt.Sprintf("%+g%%", grid.WorstPriceMove*100), fmt.Sprintf("%+g", grid.WorstVolShift*100)})
+		}
+		fmt.Println()
+		printTable([]string{"SECTOR", "TRADES", "WORST P&L", "MOVE", "IV"}, sectorRows)
+
+		if result.WorstSector != "" {
+			fmt.Printf("\nWorst-case sector: %s\n", result.WorstSector)
+		}
+		if len(result.Unpriced) > 0 {
+			fmt.Printf("Left out, no --quote: %s\n", strings.Join(result.Unpriced, ", "))
+		}
+	})
+}
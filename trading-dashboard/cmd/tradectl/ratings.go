@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+)
+
+// sectorFlag collects repeated --sector "Name=rating" values
+type sectorFlag map[string]float64
+
+func (f sectorFlag) String() string { return "" }
+
+func (f sectorFlag) Set(v string) error {
+	name, value, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("expected Name=rating, got %q", v)
+	}
+	rating, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("invalid rating for %s: %q", name, value)
+	}
+	f[strings.TrimSpace(name)] = rating
+	return nil
+}
+
+func (e *env) ratingSet(args []string) error {
+	fs := flag.NewFlagSet("rating set", flag.ContinueOnError)
+	overall := fs.Float64("overall", 0, "overall market rating (-3 to 3)")
+	sectors := sectorFlag{}
+	fs.Var(sectors, "sector", `sector rating as "Name=rating" (repeatable)`)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	valid := map[string]bool{}
+	for _, name := range models.GetSectorNames() {
+		valid[name] = true
+	}
+	for name := range sectors {
+		if !valid[name] {
+			return fmt.Errorf("unknown sector: %s", name)
+		}
+	}
+
+	rating, err := e.market.SaveRating(models.MarketRatingRequest{
+		OverallRating: *overall,
+		SectorRatings: sectors,
+	})
+	if err != nil {
+		return err
+	}
+	return printRating(*format, rating)
+}
+
+func (e *env) ratingLatest(args []string) error {
+	fs := flag.NewFlagSet("rating latest", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	rating, err := e.market.GetLatestRating()
+	if err != nil {
+		return err
+	}
+	return printRating(*format, rating)
+}
+
+// printRating renders a market rating with its sectors in name order
+func printRating(format string, rating *models.MarketRating) error {
+	return output(format, rating, func() {
+		fmt.Printf("Rating #%d saved %s\n", rating.ID, rating.CreatedAt.Local().Format("2006-01-02 15:04"))
+		rows := [][]string{{"Overall", fmt.Sprintf("%+.1f", rating.OverallRating)}}
+
+		names := make([]string, 0, len(rating.SectorRatings))
+		for name := range rating.SectorRatings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rows = append(rows, []string{name, fmt.Sprintf("%+.1f", rating.SectorRatings[name])})
+		}
+		printTable([]string{"SECTOR", "RATING"}, rows)
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+func (e *env) reportBasket(args []string) error {
+	fs := flag.NewFlagSet("report basket", flag.ContinueOnError)
+	from := fs.String("from", "", "first day of the report (default Monday of this week)")
+	to := fs.String("to", "", "last day of the report (default Sunday of this week)")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl report basket <file.pdf> [--from YYYY-MM-DD] [--to YYYY-MM-DD]")
+	}
+
+	weekStart, weekEnd := models.ReportWeek(time.Now())
+	start, err := parseDate("from", *from, weekStart)
+	if err != nil {
+		return err
+	}
+	end, err := parseDate("to", *to, weekEnd)
+	if err != nil {
+		return err
+	}
+	if *to != "" {
+		end = end.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	file, err := os.Create(positional[0])
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer file.Close()
+
+	summary, err := e.reports.WriteBasketReport(file, start, end)
+	if err != nil {
+		file.Close()
+		os.Remove(positional[0])
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	summary.Path = positional[0]
+
+	return output(*format, summary, func() {
+		fmt.Printf("Wrote %d-page basket report for %s to %s to %s\n", summary.Pages,
+			start.Format(dateLayout), end.Format(dateLayout), summary.Path)
+		fmt.Printf("%d active trades, %d expiring the following week, realized P&L %.2f\n",
+			summary.ActiveTrades, summary.Expiring, summary.RealizedPnL)
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+func (e *env) tradesRoll(args []string) error {
+	fs := flag.NewFlagSet("trades roll", flag.ContinueOnError)
+	strategy := fs.String("strategy", "", "strategy type of the new trade (default unchanged)")
+	entry := fs.String("entry", "", "entry date of the new trade (default today)")
+	expiration := fs.String("expiration", "", "expiration date of the new trade")
+	notes := fs.String("notes", "", "free-text notes")
+	var legs legFlag
+	fs.Var(&legs, "leg", "new leg as side:type:strike:qty:premium[:YYYY-MM-DD] or side:OCC-SYMBOL:qty:premium (repeatable)")
+	closePrice := fs.Float64("close-price", 0, "closing net price per share of the old position")
+	closeSide := fs.String("close-side", models.SideBuy, "closing side: buy (debit) or sell (credit)")
+	closeFees := fs.Float64("close-fees", 0, "closing fees")
+	openPrice := fs.Float64("open-price", 0, "opening net price per share of the new position")
+	openSide := fs.String("open-side", models.SideSell, "opening side: sell (credit) or buy (debit)")
+	openFees := fs.Float64("open-fees", 0, "opening fees")
+	qty := fs.Int("qty", 0, "quantity of the new position (default the quantity closed)")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || len(legs) == 0 {
+		return fmt.Errorf("usage: tradectl trades roll <id> --expiration YYYY-MM-DD --leg ... [--close-price P] [--open-price P]")
+	}
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid trade id %q", positional[0])
+	}
+
+	entryDate, err := parseDate("entry", *entry, time.Now().UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return err
+	}
+	expirationDate, err := parseDate("expiration", *expiration, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	req := models.RollRequest{
+		Trade: models.TradeRequest{
+			StrategyType:   *strategy,
+			EntryDate:      entryDate,
+			ExpirationDate: expirationDate,
+			Notes:          *notes,
+			Legs:           legs,
+		},
+	}
+	if optionalFloat(fs, "close-price", *closePrice) != nil {
+		req.Close = &models.FillRequest{Side: *closeSide, Price: *closePrice, Fees: *closeFees}
+	}
+	if optionalFloat(fs, "open-price", *openPrice) != nil {
+		quantity := *qty
+		if quantity == 0 {
+			pnl, err := e.trades.GetTradePnL(id, nil)
+			if err != nil {
+				return err
+			}
+			quantity = pnl.RemainingQuantity
+		}
+		req.Open = &models.FillRequest{Side: *openSide, Price: *openPrice, Quantity: quantity, Fees: *openFees}
+	}
+
+	trade, err := e.trades.RollTrade(id, req)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, trade, func() {
+		fmt.Printf("Rolled trade #%d into #%d: %s %s expiring %s\n",
+			id, trade.ID, trade.Ticker, trade.StrategyType, trade.ExpirationDate.Format(dateLayout))
+	})
+}
+
+func (e *env) tradesChain(args []string) error {
+	fs := flag.NewFlagSet("trades chain", flag.ContinueOnError)
+	mark := fs.Float64("mark", 0, "current net price per share of the open position")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl trades chain <id> [--mark P]")
+	}
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid trade id %q", positional[0])
+	}
+
+	chain, err := e.trades.GetRollChain(id, optionalFloat(fs, "mark", *mark))
+	if err != nil {
+		return err
+	}
+
+	return output(*format, chain, func() {
+		rows := make([][]string, 0, len(chain.Trades))
+		for _, t := range chain.Trades {
+			unrealized := ""
+			if t.UnrealizedPnL != nil {
+				unrealized = fmt.Sprintf("%.2f", *t.UnrealizedPnL)
+			}
+			rows = append(rows, []string{
+				strconv.FormatInt(t.TradeID, 10),
+				t.StrategyType,
+				t.Status,
+				strconv.Itoa(t.RemainingQuantity),
+				fmt.Sprintf("%.2f", t.RealizedPnL),
+				unrealized,
+			})
+		}
+		printTable([]string{"ID", "STRATEGY", "STATUS", "OPEN", "REALIZED", "UNREALIZED"}, rows)
+		fmt.Printf("Chain from trade #%d: realized %.2f, fees %.2f", chain.RootTradeID, chain.RealizedPnL, chain.Fees)
+		if chain.UnrealizedPnL != nil {
+			fmt.Printf(", unrealized %.2f", *chain.UnrealizedPnL)
+		}
+		fmt.Println()
+	})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+)
+
+// splitRule splits a "severity[:value]" rule flag
+func splitRule(name, v string) (string, string, error) {
+	severity, value, _ := strings.Cut(v, ":")
+	switch severity {
+	case models.SeverityBlock, models.SeverityWarn, models.SeverityOff:
+		return severity, value, nil
+	default:
+		return "", "", fmt.Errorf("--%s: severity must be block, warn or off, got %q", name, severity)
+	}
+}
+
+func (e *env) rulesShow(args []string) error {
+	fs := flag.NewFlagSet("rules show", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	rules, err := e.trades.GetTradeRules()
+	if err != nil {
+		return err
+	}
+	return output(*format, rules, func() { printRules(rules) })
+}
+
+func (e *env) rulesSet(args []string) error {
+	fs := flag.NewFlagSet("rules set", flag.ContinueOnError)
+	maxOpen := fs.String("max-open", "", `open trades per sector as "severity:N", e.g. "block:5"`)
+	direction := fs.String("rating-direction", "", `no bullish trades below -T or bearish above +T, as "severity:T", e.g. "block:1"`)
+	dte := fs.String("dte", "", `days to expiration range as "severity:MIN-MAX", e.g. "warn:14-60"`)
+	duplicate := fs.String("duplicate-week", "", "same ticker expiring in the same week: block, warn or off")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	rules, err := e.trades.GetTradeRules()
+	if err != nil {
+		return err
+	}
+
+	if *maxOpen != "" {
+		severity, value, err := splitRule("max-open", *maxOpen)
+		if err != nil {
+			return err
+		}
+		rules.MaxOpenPerSector.Severity = severity
+		if value != "" {
+			if rules.MaxOpenPerSector.Max, err = strconv.Atoi(value); err != nil {
+				return fmt.Errorf("--max-open: invalid limit %q", value)
+			}
+		}
+	}
+	if *direction != "" {
+		severity, value, err := splitRule("rating-direction", *direction)
+		if err != nil {
+			return err
+		}
+		rules.RatingDirection.Severity = severity
+		if value != "" {
+			if rules.RatingDirection.Threshold, err = strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("--rating-direction: invalid threshold %q", value)
+			}
+		}
+	}
+	if *dte != "" {
+		severity, value, err := splitRule("dte", *dte)
+		if err != nil {
+			return err
+		}
+		rules.DaysToExpiration.Severity = severity
+		if value != "" {
+			lo, hi, ok := strings.Cut(value, "-")
+			min, errMin := strconv.Atoi(lo)
+			max, errMax := strconv.Atoi(hi)
+			if !ok || errMin != nil || errMax != nil {
+				return fmt.Errorf("--dte: expected MIN-MAX, got %q", value)
+			}
+			rules.DaysToExpiration.Min, rules.DaysToExpiration.Max = min, max
+		}
+	}
+	if *duplicate != "" {
+		severity, _, err := splitRule("duplicate-week", *duplicate)
+		if err != nil {
+			return err
+		}
+		rules.DuplicateTickerWeek.Severity = severity
+	}
+
+	if err := e.trades.SaveTradeRules(rules); err != nil {
+		return err
+	}
+	return output(*format, rules, func() { printRules(rules) })
+}
+
+func printRules(rules models.TradeRules) {
+	printTable([]string{"RULE", "SEVERITY", "SETTING"}, [][]string{
+		{models.RuleMaxOpenPerSector, rules.MaxOpenPerSector.Severity,
+			fmt.Sprintf("at most %d open trades per sector", rules.MaxOpenPerSector.Max)},
+		{models.RuleRatingDirection, rules.RatingDirection.Severity,
+			fmt.Sprintf("no bullish trades below %+g, no bearish above %+g", -rules.RatingDirection.Threshold, rules.RatingDirection.Threshold)},
+		{models.RuleDaysToExpiration, rules.DaysToExpiration.Severity,
+			fmt.Sprintf("%d-%d days to expiration", rules.DaysToExpiration.Min, rules.DaysToExpiration.Max)},
+		{models.RuleDuplicateTickerWeek, rules.DuplicateTickerWeek.Severity,
+			"one open trade per ticker and expiration week"},
+	})
+}
+
+// printViolations lists broken trade rules
+func printViolations(violations []models.RuleViolation) {
+	for _, v := range violations {
+		fmt.Printf("  %-5s %s\n", v.Severity, v.Message)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/occ"
+)
+
+func (e *env) sizeTrade(args []string) error {
+	fs := flag.NewFlagSet("size trade", flag.ContinueOnError)
+	sector := fs.String("sector", "", "market sector of the underlying")
+	var legs legFlag
+	fs.Var(&legs, "leg", "one unit of the spread as side:type:strike:qty:premium[:YYYY-MM-DD] or side:OCC-SYMBOL:qty:premium (repeatable)")
+	direction := fs.String("direction", "", "bullish, bearish or neutral (default: inferred from the legs)")
+	equity := fs.Float64("equity", 0, "account equity (default: the stored setting)")
+	risk := fs.Float64("risk", 0, "max risk per trade in percent of equity (default: the stored setting, else 2)")
+	iv := fs.Float64("iv", 0, "implied volatility as a decimal, for spreads with more than one expiration")
+	rate := fs.Float64("rate", 0.045, "risk-free rate as a decimal")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	req := models.SizingRequest{
+		Sector:         *sector,
+		Direction:      *direction,
+		AccountEquity:  *equity,
+		MaxRiskPercent: *risk,
+		Volatility:     *iv,
+		Rate:           *rate,
+	}
+	for _, leg := range legs {
+		l := models.Leg{
+			OptionType:     leg.OptionType,
+			Side:           leg.Side,
+			Strike:         leg.Strike,
+			ExpirationDate: leg.ExpirationDate,
+			Quantity:       leg.Quantity,
+			Premium:        leg.Premium,
+		}
+		if leg.Symbol != "" {
+			sym, err := occ.Parse(leg.Symbol)
+			if err != nil {
+				return err
+			}
+			l.OptionType, l.Strike, l.ExpirationDate, l.Symbol = sym.OptionType, sym.Strike, sym.Expiration, sym.String()
+		}
+		req.Legs = append(req.Legs, l)
+	}
+
+	result, err := e.sizing.SizePosition(req)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, result, func() {
+		rating := "none"
+		if result.Rating != nil {
+			rating = fmt.Sprintf("%+g (%s)", *result.Rating, result.RatingSource)
+		}
+		direction := result.Direction
+		if result.DirectionInferred {
+			direction += " (inferred)"
+		}
+		fmt.Printf("Sector:         %s\n", result.Sector)
+		fmt.Printf("Rating:         %s\n", rating)
+		fmt.Printf("Direction:      %s\n", direction)
+		fmt.Printf("Max risk:       $%.2f (%g%% of $%.2f)\n", result.MaxRisk, result.MaxRiskPercent, result.AccountEquity)
+		fmt.Printf("Scale:          %.0f%%\n", result.Scale*100)
+		fmt.Printf("Risk budget:    $%.2f\n", result.RiskBudget)
+		fmt.Printf("Risk per unit:  $%.2f\n", result.RiskPerUnit)
+		fmt.Printf("Contracts:      %d\n", result.Contracts)
+		fmt.Printf("Risk used:      $%.2f\n", result.RiskUsed)
+		if result.Reason != "" {
+			fmt.Printf("\n%s\n", result.Reason)
+		}
+	})
+}
+
+func (e *env) sizeConfig(args []string) error {
+	fs := flag.NewFlagSet("size config", flag.ContinueOnError)
+	equity := fs.Float64("equity", 0, "store the account equity")
+	risk := fs.Float64("risk", 0, "store the max risk per trade in percent of equity")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *equity < 0 {
+		return fmt.Errorf("account equity cannot be negative")
+	}
+	if *risk < 0 || *risk > 100 {
+		return fmt.Errorf("max risk per trade must be between 0 and 100 percent")
+	}
+	if *equity > 0 {
+		if err := e.settings.SetSetting(models.SettingAccountEquity, strconv.FormatFloat(*equity, 'f', -1, 64)); err != nil {
+			return err
+		}
+	}
+	if *risk > 0 {
+		if err := e.settings.SetSetting(models.SettingMaxRiskPercent, strconv.FormatFloat(*risk, 'f', -1, 64)); err != nil {
+			return err
+		}
+	}
+
+	settings := map[string]string{}
+	for _, key := range []string{models.SettingAccountEquity, models.SettingMaxRiskPercent} {
+		value, ok, err := e.settings.GetSetting(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			settings[key] = value
+		}
+	}
+	return output(*format, settings, func() {
+		equity, ok := settings[models.SettingAccountEquity]
+		if !ok {
+			equity = "not set"
+		}
+		risk, ok := settings[models.SettingMaxRiskPercent]
+		if ok {
+			risk += "%"
+		} else {
+			risk = strconv.FormatFloat(models.DefaultMaxRiskPercent, 'f', -1, 64) + "% (default)"
+		}
+		fmt.Printf("Account equity:     %s\nMax risk per trade: %s\n", equity, risk)
+	})
+}
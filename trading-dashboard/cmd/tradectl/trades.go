@@ -0,0 +1,399 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/occ"
+	"trading-dashboard/pkg/services"
+)
+
+const dateLayout = "2006-01-02"
+
+// legFlag collects repeated --leg side:type:strike:qty:premium[:YYYY-MM-DD]
+// or side:OCC-SYMBOL:qty:premium values
+type legFlag []models.LegRequest
+
+func (f *legFlag) String() string { return "" }
+
+func (f *legFlag) Set(v string) error {
+	parts := strings.Split(v, ":")
+	if len(parts) == 4 {
+		return f.setSymbol(parts)
+	}
+	if len(parts) != 5 && len(parts) != 6 {
+		return fmt.Errorf("expected side:type:strike:qty:premium[:YYYY-MM-DD] or side:SYMBOL:qty:premium, got %q", v)
+	}
+
+	leg := models.LegRequest{Side: parts[0], OptionType: parts[1]}
+	var err error
+	if leg.Strike, err = strconv.ParseFloat(parts[2], 64); err != nil {
+		return fmt.Errorf("invalid strike %q", parts[2])
+	}
+	if leg.Quantity, err = strconv.Atoi(parts[3]); err != nil {
+		return fmt.Errorf("invalid quantity %q", parts[3])
+	}
+	if leg.Premium, err = strconv.ParseFloat(parts[4], 64); err != nil {
+		return fmt.Errorf("invalid premium %q", parts[4])
+	}
+	if len(parts) == 6 {
+		if leg.ExpirationDate, err = time.Parse(dateLayout, parts[5]); err != nil {
+			return fmt.Errorf("invalid leg expiration %q", parts[5])
+		}
+	}
+
+	*f = append(*f, leg)
+	return nil
+}
+
+// setSymbol parses a side:OCC-SYMBOL:qty:premium leg
+func (f *legFlag) setSymbol(parts []string) error {
+	leg := models.LegRequest{Side: parts[0], Symbol: parts[1]}
+	if _, err := occ.Parse(leg.Symbol); err != nil {
+		return err
+	}
+	var err error
+	if leg.Quantity, err = strconv.Atoi(parts[2]); err != nil {
+		return fmt.Errorf("invalid quantity %q", parts[2])
+	}
+	if leg.Premium, err = strconv.ParseFloat(parts[3], 64); err != nil {
+		return fmt.Errorf("invalid premium %q", parts[3])
+	}
+
+	*f = append(*f, leg)
+	return nil
+}
+
+// parseDate parses an optional YYYY-MM-DD flag value
+func parseDate(name, v string, def time.Time) (time.Time, error) {
+	if v == "" {
+		return def, nil
+	}
+	t, err := time.Parse(dateLayout, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s date %q: use YYYY-MM-DD", name, v)
+	}
+	return t, nil
+}
+
+// optionalFloat returns a pointer to the flag value when it was set
+func optionalFloat(fs *flag.FlagSet, name string, v float64) *float64 {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	if !set {
+		return nil
+	}
+	return &v
+}
+
+func (e *env) tradesList(args []string) error {
+	fs := flag.NewFlagSet("trades list", flag.ContinueOnError)
+	from := fs.String("from", "", "first entry date (default 90 days ago)")
+	to := fs.String("to", "", "last entry date (default today)")
+	status := fs.String("status", "", "only trades with this status")
+	ticker := fs.String("ticker", "", "only trades on this ticker")
+	sector := fs.String("sector", "", "only trades in this sector")
+	strategy := fs.String("strategy", "", "only trades with this strategy type")
+	sortBy := fs.String("sort", models.SortEntryDate, "sort key: "+strings.Join(models.GetSortKeys(), ", "))
+	desc := fs.Bool("desc", false, "sort in descending order")
+	limit := fs.Int("limit", models.DefaultQueryLimit, "maximum number of trades")
+	cursor := fs.String("cursor", "", "continue from the cursor printed by a previous page")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	end, err := parseDate("to", *to, today)
+	if err != nil {
+		return err
+	}
+	start, err := parseDate("from", *from, end.AddDate(0, 0, -90))
+	if err != nil {
+		return err
+	}
+	end = end.Add(24*time.Hour - time.Nanosecond)
+
+	query := models.TradeQuery{
+		SortBy:   *sortBy,
+		SortDesc: *desc,
+		Limit:    *limit,
+		Cursor:   *cursor,
+	}
+	// Active trades are listed if they were open at any point in the range,
+	// everything else by entry date
+	if *status == models.StatusActive {
+		query.ActiveFrom, query.ActiveTo = start, end
+	} else {
+		query.EntryFrom, query.EntryTo = start, end
+	}
+	if *status != "" {
+		query.Statuses = []string{*status}
+	}
+	if *ticker != "" {
+		query.Tickers = []string{*ticker}
+	}
+	if *sector != "" {
+		query.Sectors = []string{*sector}
+	}
+	if *strategy != "" {
+		query.Strategies = []string{*strategy}
+	}
+
+	page, err := e.trades.QueryTrades(query)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, page, func() {
+		rows := make([][]string, 0, len(page.Trades))
+		for _, t := range page.Trades {
+			rows = append(rows, []string{
+				strconv.FormatInt(t.ID, 10),
+				t.Ticker,
+				t.Sector,
+				t.StrategyType,
+				t.EntryDate.Format(dateLayout),
+				t.ExpirationDate.Format(dateLayout),
+				t.Status,
+				strconv.Itoa(len(t.Legs)),
+			})
+		}
+		printTable([]string{"ID", "TICKER", "SECTOR", "STRATEGY", "ENTRY", "EXPIRATION", "STATUS", "LEGS"}, rows)
+		if page.HasMore {
+			fmt.Printf("More trades follow; continue with --cursor %s\n", page.NextCursor)
+		}
+	})
+}
+
+func (e *env) tradesSearch(args []string) error {
+	fs := flag.NewFlagSet("trades search", flag.ContinueOnError)
+	status := fs.String("status", "", "only trades with this status")
+	strategy := fs.String("strategy", "", "only trades with this strategy type")
+	sector := fs.String("sector", "", "only trades in this sector")
+	limit := fs.Int("limit", models.DefaultSearchLimit, "maximum number of results")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("usage: tradectl trades search <query> [--status S --strategy NAME --sector S --limit N]")
+	}
+
+	results, err := e.trades.SearchTrades(strings.Join(positional, " "), models.TradeSearchFilters{
+		Status:       *status,
+		StrategyType: *strategy,
+		Sector:       *sector,
+		Limit:        *limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	return output(*format, results, func() {
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			rows = append(rows, []string{
+				strconv.FormatInt(r.Trade.ID, 10),
+				r.Trade.Ticker,
+				r.Trade.StrategyType,
+				r.Trade.EntryDate.Format(dateLayout),
+				r.Trade.Status,
+				r.Snippet,
+			})
+		}
+		printTable([]string{"ID", "TICKER", "STRATEGY", "ENTRY", "STATUS", "MATCH"}, rows)
+	})
+}
+
+func (e *env) tradesAdd(args []string) error {
+	fs := flag.NewFlagSet("trades add", flag.ContinueOnError)
+	ticker := fs.String("ticker", "", "underlying ticker")
+	sector := fs.String("sector", "", "market sector")
+	strategy := fs.String("strategy", "", "strategy type name")
+	entry := fs.String("entry", "", "entry date (default today)")
+	expiration := fs.String("expiration", "", "expiration date")
+	target := fs.Float64("target", 0, "target price")
+	stop := fs.Float64("stop", 0, "stop loss")
+	notes := fs.String("notes", "", "free-text notes")
+	planned := fs.Bool("planned", false, "record as a planned trade that is not yet open")
+	checkOnly := fs.Bool("check", false, "only list the trade rules the trade would break")
+	var legs legFlag
+	fs.Var(&legs, "leg", "leg as side:type:strike:qty:premium[:YYYY-MM-DD] or side:OCC-SYMBOL:qty:premium (repeatable)")
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	entryDate, err := parseDate("entry", *entry, time.Now().UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return err
+	}
+	expirationDate, err := parseDate("expiration", *expiration, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	status := models.StatusActive
+	if *planned {
+		status = models.StatusPlanned
+	}
+
+	req := models.TradeRequest{
+		Ticker:         strings.ToUpper(*ticker),
+		Sector:         *sector,
+		StrategyType:   *strategy,
+		EntryDate:      entryDate,
+		ExpirationDate: expirationDate,
+		TargetPrice:    optionalFloat(fs, "target", *target),
+		StopLoss:       optionalFloat(fs, "stop", *stop),
+		Notes:          *notes,
+		Status:         status,
+		Legs:           legs,
+	}
+
+	if *checkOnly {
+		check, err := e.trades.CheckTradeRules(req)
+		if err != nil {
+			return err
+		}
+		return output(*format, check, func() {
+			switch {
+			case len(check.Violations) == 0:
+				fmt.Println("No trade rules broken")
+			case check.Blocked:
+				fmt.Println("Blocked by trade rules:")
+			default:
+				fmt.Println("Allowed with warnings:")
+			}
+			printViolations(check.Violations)
+		})
+	}
+
+	trade, err := e.trades.CreateTrade(req)
+	var ruleErr *services.RuleError
+	if errors.As(err, &ruleErr) && *format != "json" {
+		fmt.Println("Blocked by trade rules:")
+		printViolations(ruleErr.Violations)
+		return fmt.Errorf("trade not created")
+	}
+	if err != nil {
+		return err
+	}
+
+	return output(*format, trade, func() {
+		fmt.Printf("Created trade #%d: %s %s expiring %s with %d leg(s)\n",
+			trade.ID, trade.Ticker, trade.StrategyType, trade.ExpirationDate.Format(dateLayout), len(trade.Legs))
+		if len(trade.Warnings) > 0 {
+			fmt.Println("Warnings:")
+			printViolations(trade.Warnings)
+		}
+	})
+}
+
+func (e *env) tradesClose(args []string) error {
+	fs := flag.NewFlagSet("trades close", flag.ContinueOnError)
+	price := fs.Float64("price", 0, "closing net price per share; records a closing fill")
+	side := fs.String("side", "", "closing side: buy (debit) or sell (credit); defaults to offsetting the opening fills")
+	fees := fs.Float64("fees", 0, "closing fees")
+	qty := fs.Int("qty", 0, "quantity to close (default all)")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tradectl trades close <id> [--price P --side buy|sell --fees F --qty N]")
+	}
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid trade id %q", positional[0])
+	}
+
+	var trade *models.OptionsTrade
+	if optionalFloat(fs, "price", *price) != nil {
+		trade, err = e.trades.CloseTrade(id, models.FillRequest{
+			Side:     *side,
+			Price:    *price,
+			Quantity: *qty,
+			Fees:     *fees,
+		})
+	} else {
+		// Without a price only trades with nothing left open can be closed
+		var pnl *models.TradePnL
+		if pnl, err = e.trades.GetTradePnL(id, nil); err != nil {
+			return err
+		}
+		if pnl.RemainingQuantity > 0 {
+			return fmt.Errorf("trade #%d has %d open; pass --price to record the closing fill", id, pnl.RemainingQuantity)
+		}
+		trade, err = e.trades.UpdateTradeStatus(id, models.StatusClosed)
+	}
+	if err != nil {
+		return err
+	}
+
+	return output(*format, trade, func() {
+		if trade.Status != models.StatusClosed {
+			fmt.Printf("Partially closed trade #%d (%s %s); it stays %s\n", trade.ID, trade.Ticker, trade.StrategyType, trade.Status)
+			return
+		}
+		fmt.Printf("Closed trade #%d (%s %s)\n", trade.ID, trade.Ticker, trade.StrategyType)
+	})
+}
+
+func (e *env) tradesStatus(args []string) error {
+	fs := flag.NewFlagSet("trades status", flag.ContinueOnError)
+	reason := fs.String("reason", "", "why the status changed")
+	format := formatFlag(fs)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("usage: tradectl trades status <id> <status> [--reason TEXT]")
+	}
+	id, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid trade id %q", positional[0])
+	}
+
+	trade, err := e.trades.TransitionTrade(id, positional[1], *reason)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, trade, func() {
+		fmt.Printf("Trade #%d (%s %s) is now %s\n", trade.ID, trade.Ticker, trade.StrategyType, trade.Status)
+	})
+}
+
+func (e *env) tradesExpire(args []string) error {
+	fs := flag.NewFlagSet("trades expire", flag.ContinueOnError)
+	format := formatFlag(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	changes, err := e.trades.ExpireOverdueTrades(time.Now())
+	if err != nil {
+		return err
+	}
+
+	return output(*format, changes, func() {
+		fmt.Printf("Expired %d trade(s)\n", len(changes))
+		for _, c := range changes {
+			fmt.Printf("  #%d %s -> %s\n", c.TradeID, c.FromStatus, c.ToStatus)
+		}
+	})
+}
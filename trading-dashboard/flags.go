@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"strings"
+
+	"trading-dashboard/pkg/api"
+)
+
+// parseAPIFlags reads the command-line flags that enable the local REST API.
+// Unknown flags are ignored because Wails passes its own flags in dev mode.
+func parseAPIFlags(args []string) *api.Config {
+	fs := flag.NewFlagSet("trading-dashboard", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	enabled := fs.Bool("api", false, "serve the REST API alongside the desktop window")
+	addr := fs.String("api-addr", api.DefaultAddr, "listen address for the REST API")
+	origins := fs.String("api-origins", "", "comma-separated browser origins allowed to call the REST API")
+	_ = fs.Parse(args)
+
+	if !*enabled {
+		return nil
+	}
+
+	cfg := &api.Config{Addr: *addr}
+	for _, o := range strings.Split(*origins, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			cfg.AllowedOrigins = append(cfg.AllowedOrigins, o)
+		}
+	}
+	return cfg
+}
@@ -0,0 +1,30 @@
+import { writable } from 'svelte/store';
+
+// Number of unread alerts, shown on the navigation bar
+export const unreadAlerts = writable(0);
+
+export async function refreshUnreadAlerts() {
+	try {
+		unreadAlerts.set(await window['go']['main']['App']['GetUnreadAlertCount']());
+	} catch (error) {
+		console.error('Failed to count unread alerts:', error);
+	}
+}
+
+// Wails v2 has no native notification API, so use the webview's where it
+// exists and the user allows it; the toast shows either way
+export function notifyDesktop(alerts) {
+	if (typeof Notification === 'undefined' || Notification.permission === 'denied') return;
+	const show = () => {
+		for (const alert of alerts) {
+			new Notification('Trading Dashboard', { body: alert.message });
+		}
+	};
+	if (Notification.permission === 'granted') {
+		show();
+	} else {
+		Notification.requestPermission().then((permission) => {
+			if (permission === 'granted') show();
+		});
+	}
+}
@@ -0,0 +1,18 @@
+import { writable } from 'svelte/store';
+
+// Underlying prices and implied volatilities entered for the current session,
+// shared by the portfolio Greeks and scenario panels. Keyed by upper-case
+// ticker; iv is in percent as typed.
+export const quotes = writable({});
+
+// toRequestQuotes converts entered quotes to the backend's Quote map,
+// skipping tickers without a price
+export function toRequestQuotes(entered) {
+	const result = {};
+	for (const [ticker, quote] of Object.entries(entered)) {
+		if (quote.price !== '' && Number(quote.price) > 0) {
+			result[ticker] = { price: Number(quote.price), volatility: Number(quote.iv || 0) / 100 };
+		}
+	}
+	return result;
+}
@@ -20,6 +20,7 @@ function createTradesStore() {
 	const { subscribe, set, update } = writable({
 		trades: [],
 		strategyTypes: [],
+		statusTransitions: {},
 		sectors: SECTORS,
 		dateColumns: [],
 		loading: false,
@@ -145,6 +146,40 @@ function createTradesStore() {
 			}
 		},
 		
+		// Search tickers and notes across the whole trade history
+		queryTrades: async (query) => {
+			try {
+				return await window['go']['main']['App']['QueryTrades'](query);
+			} catch (error) {
+				console.error('Failed to query trades:', error);
+				throw error;
+			}
+		},
+
+		searchTrades: async (query, filters = {}) => {
+			try {
+				return await window['go']['main']['App']['SearchTrades'](query, filters) || [];
+			} catch (error) {
+				console.error('Failed to search trades:', error);
+				throw error;
+			}
+		},
+		
+		// Load the allowed status transitions of the trade lifecycle
+		loadStatusTransitions: async () => {
+			try {
+				const transitions = await window['go']['main']['App']['GetStatusTransitions']();
+				update(state => ({
+					...state,
+					statusTransitions: transitions || {}
+				}));
+				return transitions;
+			} catch (error) {
+				console.error('Failed to load status transitions:', error);
+				return {};
+			}
+		},
+		
 		// Update trade status
 		updateTradeStatus: async (id, status) => {
 			try {
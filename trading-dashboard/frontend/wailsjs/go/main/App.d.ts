@@ -1,30 +1,152 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {models} from '../models';
+import {payoff} from '../models';
+import {pricing} from '../models';
 import {time} from '../models';
+import {occ} from '../models';
+import {importer} from '../models';
+
+export function AddTradeFill(arg1:number,arg2:models.FillRequest):Promise<models.Fill>;
+
+export function BuildStrategyLegs(arg1:string,arg2:payoff.TemplateSpec):Promise<Array<models.Leg>>;
+
+export function CalculateOptionGreeks(arg1:pricing.Inputs):Promise<pricing.Greeks>;
+
+export function CalculatePayoff(arg1:Array<models.Leg>,arg2:payoff.Options):Promise<payoff.Analysis>;
+
+export function CalculateTradeGreeks(arg1:number,arg2:models.GreeksRequest):Promise<models.PositionGreeks>;
+
+export function CheckAlertsNow():Promise<Array<models.Alert>>;
+
+export function CheckTradeRules(arg1:models.TradeRequest):Promise<models.RuleCheck>;
 
 export function Close():Promise<void>;
 
+export function CloseTrade(arg1:number,arg2:models.FillRequest):Promise<models.OptionsTrade>;
+
+export function CompareMarketRatings(arg1:number,arg2:number):Promise<models.RatingComparison>;
+
+export function CreateBackup():Promise<models.BackupInfo>;
+
 export function CreateTrade(arg1:models.TradeRequest):Promise<models.OptionsTrade>;
 
+export function DeleteBeta(arg1:string):Promise<void>;
+
 export function DeleteTrade(arg1:number):Promise<void>;
 
+export function DeleteTradeFill(arg1:number):Promise<void>;
+
+export function DeleteUnderlyingPrice(arg1:string):Promise<void>;
+
+export function ExpireOverdueTrades():Promise<Array<models.StatusChange>>;
+
+export function ExportArchive():Promise<models.ArchiveSummary>;
+
+export function ExportBasketReport(arg1:time.Time,arg2:time.Time):Promise<models.ReportSummary>;
+
+export function ExportWorkbook(arg1:models.TradeQuery):Promise<models.WorkbookSummary>;
+
+export function FormatOCCSymbol(arg1:occ.Symbol):Promise<string>;
+
 export function GetActiveTradesByDateRange(arg1:time.Time,arg2:time.Time):Promise<Array<models.OptionsTrade>>;
 
+export function GetAlertRules():Promise<models.AlertRules>;
+
+export function GetAlerts(arg1:boolean,arg2:number):Promise<Array<models.Alert>>;
+
+export function GetBetas():Promise<Array<models.Beta>>;
+
+export function GetImportBrokers():Promise<Array<string>>;
+
 export function GetLatestMarketRating():Promise<models.MarketRating>;
 
+export function GetMarketRatingHistory(arg1:time.Time,arg2:time.Time):Promise<Array<models.MarketRating>>;
+
+export function GetPortfolioGreeks(arg1:models.PortfolioGreeksRequest):Promise<models.PortfolioGreeks>;
+
+export function GetRealizedPnL(arg1:time.Time,arg2:time.Time):Promise<models.PnLSummary>;
+
+export function GetRollChain(arg1:number,arg2:any):Promise<models.RollChain>;
+
 export function GetSectorNames():Promise<Array<string>>;
 
+export function GetSectorRatingHistory(arg1:string,arg2:time.Time,arg3:time.Time):Promise<Array<models.SectorRatingPoint>>;
+
+export function GetSentimentEdgeReport(arg1:time.Time,arg2:time.Time):Promise<models.SentimentEdgeReport>;
+
+export function GetSettings():Promise<Record<string, string>>;
+
+export function GetStatusTransitions():Promise<Record<string, Array<string>>>;
+
 export function GetStrategyTypes():Promise<Array<models.StrategyType>>;
 
 export function GetTradeByID(arg1:number):Promise<models.OptionsTrade>;
 
+export function GetTradeFills(arg1:number):Promise<Array<models.Fill>>;
+
+export function GetTradePayoff(arg1:number,arg2:payoff.Options):Promise<payoff.Analysis>;
+
+export function GetTradePnL(arg1:number,arg2:any):Promise<models.TradePnL>;
+
+export function GetTradeRules():Promise<models.TradeRules>;
+
+export function GetTradeSortKeys():Promise<Array<string>>;
+
+export function GetTradeStatusHistory(arg1:number):Promise<Array<models.StatusChange>>;
+
 export function GetTrades(arg1:time.Time,arg2:time.Time):Promise<Array<models.OptionsTrade>>;
 
+export function GetUnderlyingPrices():Promise<Array<models.UnderlyingPrice>>;
+
+export function GetUnreadAlertCount():Promise<number>;
+
 export function Greet(arg1:string):Promise<string>;
 
+export function ImportArchive(arg1:string,arg2:string):Promise<models.ArchiveImportResult>;
+
+export function ImportTrades(arg1:string,arg2:string,arg3:importer.Options):Promise<models.ImportResult>;
+
+export function ListBackups():Promise<Array<models.BackupInfo>>;
+
+export function MarkAlertRead(arg1:number):Promise<void>;
+
+export function MarkAllAlertsRead():Promise<number>;
+
+export function ParseOCCSymbol(arg1:string):Promise<occ.Symbol>;
+
+export function PreviewImport(arg1:string,arg2:string,arg3:importer.Options):Promise<models.ImportPreview>;
+
+export function QueryTrades(arg1:models.TradeQuery):Promise<models.TradePage>;
+
+export function RestoreBackup(arg1:string):Promise<models.BackupInfo>;
+
+export function RollTrade(arg1:number,arg2:models.RollRequest):Promise<models.OptionsTrade>;
+
+export function RunScenario(arg1:models.ScenarioRequest):Promise<models.ScenarioResult>;
+
+export function SaveAlertRules(arg1:models.AlertRules):Promise<void>;
+
 export function SaveMarketRating(arg1:models.MarketRatingRequest):Promise<models.MarketRating>;
 
+export function SaveTradeRules(arg1:models.TradeRules):Promise<void>;
+
+export function SearchTrades(arg1:string,arg2:models.TradeSearchFilters):Promise<Array<models.TradeSearchResult>>;
+
+export function SelectArchiveFile():Promise<string>;
+
+export function SelectImportFile():Promise<string>;
+
+export function SetBeta(arg1:string,arg2:number):Promise<void>;
+
+export function SetSetting(arg1:string,arg2:string):Promise<void>;
+
+export function SetUnderlyingPrice(arg1:string,arg2:number):Promise<void>;
+
+export function SizePosition(arg1:models.SizingRequest):Promise<models.SizingResult>;
+
+export function SolveImpliedVolatility(arg1:pricing.Inputs,arg2:number):Promise<number>;
+
 export function UpdateMarketRating(arg1:number,arg2:models.MarketRatingRequest):Promise<models.MarketRating>;
 
 export function UpdateTrade(arg1:number,arg2:models.TradeRequest):Promise<models.OptionsTrade>;
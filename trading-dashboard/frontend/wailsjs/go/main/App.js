@@ -2,30 +2,150 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddTradeFill(arg1, arg2) {
+  return window['go']['main']['App']['AddTradeFill'](arg1, arg2);
+}
+
+export function BuildStrategyLegs(arg1, arg2) {
+  return window['go']['main']['App']['BuildStrategyLegs'](arg1, arg2);
+}
+
+export function CalculateOptionGreeks(arg1) {
+  return window['go']['main']['App']['CalculateOptionGreeks'](arg1);
+}
+
+export function CalculatePayoff(arg1, arg2) {
+  return window['go']['main']['App']['CalculatePayoff'](arg1, arg2);
+}
+
+export function CalculateTradeGreeks(arg1, arg2) {
+  return window['go']['main']['App']['CalculateTradeGreeks'](arg1, arg2);
+}
+
+export function CheckAlertsNow() {
+  return window['go']['main']['App']['CheckAlertsNow']();
+}
+
+export function CheckTradeRules(arg1) {
+  return window['go']['main']['App']['CheckTradeRules'](arg1);
+}
+
 export function Close() {
   return window['go']['main']['App']['Close']();
 }
 
+export function CloseTrade(arg1, arg2) {
+  return window['go']['main']['App']['CloseTrade'](arg1, arg2);
+}
+
+export function CompareMarketRatings(arg1, arg2) {
+  return window['go']['main']['App']['CompareMarketRatings'](arg1, arg2);
+}
+
+export function CreateBackup() {
+  return window['go']['main']['App']['CreateBackup']();
+}
+
 export function CreateTrade(arg1) {
   return window['go']['main']['App']['CreateTrade'](arg1);
 }
 
+export function DeleteBeta(arg1) {
+  return window['go']['main']['App']['DeleteBeta'](arg1);
+}
+
 export function DeleteTrade(arg1) {
   return window['go']['main']['App']['DeleteTrade'](arg1);
 }
 
+export function DeleteTradeFill(arg1) {
+  return window['go']['main']['App']['DeleteTradeFill'](arg1);
+}
+
+export function DeleteUnderlyingPrice(arg1) {
+  return window['go']['main']['App']['DeleteUnderlyingPrice'](arg1);
+}
+
+export function ExpireOverdueTrades() {
+  return window['go']['main']['App']['ExpireOverdueTrades']();
+}
+
+export function ExportArchive() {
+  return window['go']['main']['App']['ExportArchive']();
+}
+
+export function ExportBasketReport(arg1, arg2) {
+  return window['go']['main']['App']['ExportBasketReport'](arg1, arg2);
+}
+
+export function ExportWorkbook(arg1) {
+  return window['go']['main']['App']['ExportWorkbook'](arg1);
+}
+
+export function FormatOCCSymbol(arg1) {
+  return window['go']['main']['App']['FormatOCCSymbol'](arg1);
+}
+
 export function GetActiveTradesByDateRange(arg1, arg2) {
   return window['go']['main']['App']['GetActiveTradesByDateRange'](arg1, arg2);
 }
 
+export function GetAlertRules() {
+  return window['go']['main']['App']['GetAlertRules']();
+}
+
+export function GetAlerts(arg1, arg2) {
+  return window['go']['main']['App']['GetAlerts'](arg1, arg2);
+}
+
+export function GetBetas() {
+  return window['go']['main']['App']['GetBetas']();
+}
+
+export function GetImportBrokers() {
+  return window['go']['main']['App']['GetImportBrokers']();
+}
+
 export function GetLatestMarketRating() {
   return window['go']['main']['App']['GetLatestMarketRating']();
 }
 
+export function GetMarketRatingHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetMarketRatingHistory'](arg1, arg2);
+}
+
+export function GetPortfolioGreeks(arg1) {
+  return window['go']['main']['App']['GetPortfolioGreeks'](arg1);
+}
+
+export function GetRealizedPnL(arg1, arg2) {
+  return window['go']['main']['App']['GetRealizedPnL'](arg1, arg2);
+}
+
+export function GetRollChain(arg1, arg2) {
+  return window['go']['main']['App']['GetRollChain'](arg1, arg2);
+}
+
 export function GetSectorNames() {
   return window['go']['main']['App']['GetSectorNames']();
 }
 
+export function GetSectorRatingHistory(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetSectorRatingHistory'](arg1, arg2, arg3);
+}
+
+export function GetSentimentEdgeReport(arg1, arg2) {
+  return window['go']['main']['App']['GetSentimentEdgeReport'](arg1, arg2);
+}
+
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
+}
+
+export function GetStatusTransitions() {
+  return window['go']['main']['App']['GetStatusTransitions']();
+}
+
 export function GetStrategyTypes() {
   return window['go']['main']['App']['GetStrategyTypes']();
 }
@@ -34,18 +154,134 @@ export function GetTradeByID(arg1) {
   return window['go']['main']['App']['GetTradeByID'](arg1);
 }
 
+export function GetTradeFills(arg1) {
+  return window['go']['main']['App']['GetTradeFills'](arg1);
+}
+
+export function GetTradePayoff(arg1, arg2) {
+  return window['go']['main']['App']['GetTradePayoff'](arg1, arg2);
+}
+
+export function GetTradePnL(arg1, arg2) {
+  return window['go']['main']['App']['GetTradePnL'](arg1, arg2);
+}
+
+export function GetTradeRules() {
+  return window['go']['main']['App']['GetTradeRules']();
+}
+
+export function GetTradeSortKeys() {
+  return window['go']['main']['App']['GetTradeSortKeys']();
+}
+
+export function GetTradeStatusHistory(arg1) {
+  return window['go']['main']['App']['GetTradeStatusHistory'](arg1);
+}
+
 export function GetTrades(arg1, arg2) {
   return window['go']['main']['App']['GetTrades'](arg1, arg2);
 }
 
+export function GetUnderlyingPrices() {
+  return window['go']['main']['App']['GetUnderlyingPrices']();
+}
+
+export function GetUnreadAlertCount() {
+  return window['go']['main']['App']['GetUnreadAlertCount']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function ImportArchive(arg1, arg2) {
+  return window['go']['main']['App']['ImportArchive'](arg1, arg2);
+}
+
+export function ImportTrades(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportTrades'](arg1, arg2, arg3);
+}
+
+export function ListBackups() {
+  return window['go']['main']['App']['ListBackups']();
+}
+
+export function MarkAlertRead(arg1) {
+  return window['go']['main']['App']['MarkAlertRead'](arg1);
+}
+
+export function MarkAllAlertsRead() {
+  return window['go']['main']['App']['MarkAllAlertsRead']();
+}
+
+export function ParseOCCSymbol(arg1) {
+  return window['go']['main']['App']['ParseOCCSymbol'](arg1);
+}
+
+export function PreviewImport(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewImport'](arg1, arg2, arg3);
+}
+
+export function QueryTrades(arg1) {
+  return window['go']['main']['App']['QueryTrades'](arg1);
+}
+
+export function RestoreBackup(arg1) {
+  return window['go']['main']['App']['RestoreBackup'](arg1);
+}
+
+export function RollTrade(arg1, arg2) {
+  return window['go']['main']['App']['RollTrade'](arg1, arg2);
+}
+
+export function RunScenario(arg1) {
+  return window['go']['main']['App']['RunScenario'](arg1);
+}
+
+export function SaveAlertRules(arg1) {
+  return window['go']['main']['App']['SaveAlertRules'](arg1);
+}
+
 export function SaveMarketRating(arg1) {
   return window['go']['main']['App']['SaveMarketRating'](arg1);
 }
 
+export function SaveTradeRules(arg1) {
+  return window['go']['main']['App']['SaveTradeRules'](arg1);
+}
+
+export function SearchTrades(arg1, arg2) {
+  return window['go']['main']['App']['SearchTrades'](arg1, arg2);
+}
+
+export function SelectArchiveFile() {
+  return window['go']['main']['App']['SelectArchiveFile']();
+}
+
+export function SelectImportFile() {
+  return window['go']['main']['App']['SelectImportFile']();
+}
+
+export function SetBeta(arg1, arg2) {
+  return window['go']['main']['App']['SetBeta'](arg1, arg2);
+}
+
+export function SetSetting(arg1, arg2) {
+  return window['go']['main']['App']['SetSetting'](arg1, arg2);
+}
+
+export function SetUnderlyingPrice(arg1, arg2) {
+  return window['go']['main']['App']['SetUnderlyingPrice'](arg1, arg2);
+}
+
+export function SizePosition(arg1) {
+  return window['go']['main']['App']['SizePosition'](arg1);
+}
+
+export function SolveImpliedVolatility(arg1, arg2) {
+  return window['go']['main']['App']['SolveImpliedVolatility'](arg1, arg2);
+}
+
 export function UpdateMarketRating(arg1, arg2) {
   return window['go']['main']['App']['UpdateMarketRating'](arg1, arg2);
 }
@@ -1,23 +1,46 @@
+export namespace importer {
+	
+	export class Options {
+	    default_sector: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.default_sector = source["default_sector"];
+	    }
+	}
+
+}
+
 export namespace models {
 	
-	export class MarketRating {
+	export class Alert {
 	    id: number;
-	    overall_rating: number;
-	    sector_ratings: Record<string, number>;
+	    rule: string;
+	    trade_id?: number;
+	    ticker: string;
+	    sector: string;
+	    message: string;
 	    created_at: time.Time;
-	    updated_at: time.Time;
+	    read_at?: time.Time;
 	
 	    static createFrom(source: any = {}) {
-	        return new MarketRating(source);
+	        return new Alert(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.overall_rating = source["overall_rating"];
-	        this.sector_ratings = source["sector_ratings"];
+	        this.rule = source["rule"];
+	        this.trade_id = source["trade_id"];
+	        this.ticker = source["ticker"];
+	        this.sector = source["sector"];
+	        this.message = source["message"];
 	        this.created_at = this.convertValues(source["created_at"], time.Time);
-	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	        this.read_at = this.convertValues(source["read_at"], time.Time);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -38,51 +61,214 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class MarketRatingRequest {
-	    overall_rating: number;
-	    sector_ratings: Record<string, number>;
+	export class ExpiringAlertRule {
+	    enabled: boolean;
+	    days: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new MarketRatingRequest(source);
+	        return new ExpiringAlertRule(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.overall_rating = source["overall_rating"];
-	        this.sector_ratings = source["sector_ratings"];
+	        this.enabled = source["enabled"];
+	        this.days = source["days"];
 	    }
 	}
-	export class OptionsTrade {
-	    id: number;
-	    ticker: string;
-	    sector: string;
-	    strategy_type: string;
-	    entry_date: time.Time;
-	    expiration_date: time.Time;
-	    target_price?: number;
-	    stop_loss?: number;
-	    status: string;
-	    notes: string;
+	export class AlertToggle {
+	    enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AlertToggle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	    }
+	}
+	export class AlertRules {
+	    target_stop: AlertToggle;
+	    expiring: ExpiringAlertRule;
+	    rating_sign: AlertToggle;
+	
+	    static createFrom(source: any = {}) {
+	        return new AlertRules(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.target_stop = this.convertValues(source["target_stop"], AlertToggle);
+	        this.expiring = this.convertValues(source["expiring"], ExpiringAlertRule);
+	        this.rating_sign = this.convertValues(source["rating_sign"], AlertToggle);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ArchiveCounts {
+	    market_ratings: number;
+	    strategy_types: number;
+	    trades: number;
+	    settings: number;
+	    betas: number;
+	    underlying_prices: number;
+	    alerts: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArchiveCounts(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.market_ratings = source["market_ratings"];
+	        this.strategy_types = source["strategy_types"];
+	        this.trades = source["trades"];
+	        this.settings = source["settings"];
+	        this.betas = source["betas"];
+	        this.underlying_prices = source["underlying_prices"];
+	        this.alerts = source["alerts"];
+	    }
+	}
+	export class ArchiveImportResult {
+	    mode: string;
+	    exported_at: time.Time;
+	    imported: ArchiveCounts;
+	    skipped: ArchiveCounts;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArchiveImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.exported_at = this.convertValues(source["exported_at"], time.Time);
+	        this.imported = this.convertValues(source["imported"], ArchiveCounts);
+	        this.skipped = this.convertValues(source["skipped"], ArchiveCounts);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ArchiveSummary {
+	    path: string;
+	    exported_at: time.Time;
+	    checksum: string;
+	    counts: ArchiveCounts;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArchiveSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.exported_at = this.convertValues(source["exported_at"], time.Time);
+	        this.checksum = source["checksum"];
+	        this.counts = this.convertValues(source["counts"], ArchiveCounts);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BackupInfo {
+	    name: string;
+	    path: string;
+	    reason: string;
+	    size: number;
 	    created_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.reason = source["reason"];
+	        this.size = source["size"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Beta {
+	    ticker: string;
+	    beta: number;
 	    updated_at: time.Time;
 	
 	    static createFrom(source: any = {}) {
-	        return new OptionsTrade(source);
+	        return new Beta(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
 	        this.ticker = source["ticker"];
-	        this.sector = source["sector"];
-	        this.strategy_type = source["strategy_type"];
-	        this.entry_date = this.convertValues(source["entry_date"], time.Time);
-	        this.expiration_date = this.convertValues(source["expiration_date"], time.Time);
-	        this.target_price = source["target_price"];
-	        this.stop_loss = source["stop_loss"];
-	        this.status = source["status"];
-	        this.notes = source["notes"];
-	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.beta = source["beta"];
 	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
 	    }
 	
@@ -104,50 +290,93 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class StrategyType {
+	export class DaysToExpirationRule {
+	    severity: string;
+	    min: number;
+	    max: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DaysToExpirationRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	    }
+	}
+	
+	export class Fill {
 	    id: number;
-	    name: string;
-	    category: string;
-	    description: string;
-	    color_hex: string;
+	    trade_id: number;
+	    action: string;
+	    side: string;
+	    price: number;
+	    quantity: number;
+	    fees: number;
+	    filled_at: time.Time;
+	    external_id?: string;
+	    created_at: time.Time;
 	
 	    static createFrom(source: any = {}) {
-	        return new StrategyType(source);
+	        return new Fill(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.category = source["category"];
-	        this.description = source["description"];
-	        this.color_hex = source["color_hex"];
+	        this.trade_id = source["trade_id"];
+	        this.action = source["action"];
+	        this.side = source["side"];
+	        this.price = source["price"];
+	        this.quantity = source["quantity"];
+	        this.fees = source["fees"];
+	        this.filled_at = this.convertValues(source["filled_at"], time.Time);
+	        this.external_id = source["external_id"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class TradeRequest {
-	    ticker: string;
-	    sector: string;
-	    strategy_type: string;
-	    entry_date: time.Time;
-	    expiration_date: time.Time;
-	    target_price?: number;
-	    stop_loss?: number;
-	    notes: string;
+	export class FillRequest {
+	    action: string;
+	    side: string;
+	    price: number;
+	    quantity: number;
+	    fees: number;
+	    filled_at: time.Time;
+	    external_id?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TradeRequest(source);
+	        return new FillRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.ticker = source["ticker"];
-	        this.sector = source["sector"];
-	        this.strategy_type = source["strategy_type"];
-	        this.entry_date = this.convertValues(source["entry_date"], time.Time);
-	        this.expiration_date = this.convertValues(source["expiration_date"], time.Time);
-	        this.target_price = source["target_price"];
-	        this.stop_loss = source["stop_loss"];
-	        this.notes = source["notes"];
+	        this.action = source["action"];
+	        this.side = source["side"];
+	        this.price = source["price"];
+	        this.quantity = source["quantity"];
+	        this.fees = source["fees"];
+	        this.filled_at = this.convertValues(source["filled_at"], time.Time);
+	        this.external_id = source["external_id"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -168,6 +397,1847 @@ export namespace models {
 		    return a;
 		}
 	}
+	export class GreeksBucket {
+	    label: string;
+	    trades: number;
+	    delta: number;
+	    gamma: number;
+	    theta: number;
+	    vega: number;
+	    beta_weighted_delta: number;
+	    rating?: number;
+	    bias?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GreeksBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
+	        this.trades = source["trades"];
+	        this.delta = source["delta"];
+	        this.gamma = source["gamma"];
+	        this.theta = source["theta"];
+	        this.vega = source["vega"];
+	        this.beta_weighted_delta = source["beta_weighted_delta"];
+	        this.rating = source["rating"];
+	        this.bias = source["bias"];
+	    }
+	}
+	export class GreeksRequest {
+	    underlying_price: number;
+	    volatility: number;
+	    rate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GreeksRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.underlying_price = source["underlying_price"];
+	        this.volatility = source["volatility"];
+	        this.rate = source["rate"];
+	    }
+	}
+	export class ImportedClose {
+	    external_id: string;
+	    ticker: string;
+	    trade_id?: number;
+	    trade_external_id?: string;
+	    fill: FillRequest;
+	    adjustment: boolean;
+	    duplicate: boolean;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportedClose(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.external_id = source["external_id"];
+	        this.ticker = source["ticker"];
+	        this.trade_id = source["trade_id"];
+	        this.trade_external_id = source["trade_external_id"];
+	        this.fill = this.convertValues(source["fill"], FillRequest);
+	        this.adjustment = source["adjustment"];
+	        this.duplicate = source["duplicate"];
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LegRequest {
+	    option_type: string;
+	    side: string;
+	    strike: number;
+	    expiration_date: time.Time;
+	    quantity: number;
+	    premium: number;
+	    symbol?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LegRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.option_type = source["option_type"];
+	        this.side = source["side"];
+	        this.strike = source["strike"];
+	        this.expiration_date = this.convertValues(source["expiration_date"], time.Time);
+	        this.quantity = source["quantity"];
+	        this.premium = source["premium"];
+	        this.symbol = source["symbol"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TradeRequest {
+	    ticker: string;
+	    sector: string;
+	    strategy_type: string;
+	    entry_date: time.Time;
+	    expiration_date: time.Time;
+	    target_price?: number;
+	    stop_loss?: number;
+	    notes: string;
+	    legs?: LegRequest[];
+	    status?: string;
+	    external_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TradeRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ticker = source["ticker"];
+	        this.sector = source["sector"];
+	        this.strategy_type = source["strategy_type"];
+	        this.entry_date = this.convertValues(source["entry_date"], time.Time);
+	        this.expiration_date = this.convertValues(source["expiration_date"], time.Time);
+	        this.target_price = source["target_price"];
+	        this.stop_loss = source["stop_loss"];
+	        this.notes = source["notes"];
+	        this.legs = this.convertValues(source["legs"], LegRequest);
+	        this.status = source["status"];
+	        this.external_id = source["external_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportedTrade {
+	    external_id: string;
+	    request: TradeRequest;
+	    open_fill: FillRequest;
+	    duplicate: boolean;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportedTrade(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.external_id = source["external_id"];
+	        this.request = this.convertValues(source["request"], TradeRequest);
+	        this.open_fill = this.convertValues(source["open_fill"], FillRequest);
+	        this.duplicate = source["duplicate"];
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportPreview {
+	    broker: string;
+	    trades: ImportedTrade[];
+	    closes: ImportedClose[];
+	    skipped: string[];
+	    duplicates: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.broker = source["broker"];
+	        this.trades = this.convertValues(source["trades"], ImportedTrade);
+	        this.closes = this.convertValues(source["closes"], ImportedClose);
+	        this.skipped = source["skipped"];
+	        this.duplicates = source["duplicates"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportResult {
+	    created_trades: number[];
+	    closed_trades: number[];
+	    recorded_fills: number;
+	    skipped_duplicates: number;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.created_trades = source["created_trades"];
+	        this.closed_trades = source["closed_trades"];
+	        this.recorded_fills = source["recorded_fills"];
+	        this.skipped_duplicates = source["skipped_duplicates"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	
+	
+	export class Leg {
+	    id: number;
+	    trade_id: number;
+	    option_type: string;
+	    side: string;
+	    strike: number;
+	    expiration_date: time.Time;
+	    quantity: number;
+	    premium: number;
+	    symbol?: string;
+	    created_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new Leg(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.trade_id = source["trade_id"];
+	        this.option_type = source["option_type"];
+	        this.side = source["side"];
+	        this.strike = source["strike"];
+	        this.expiration_date = this.convertValues(source["expiration_date"], time.Time);
+	        this.quantity = source["quantity"];
+	        this.premium = source["premium"];
+	        this.symbol = source["symbol"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LegGreeks {
+	    leg_id: number;
+	    greeks: pricing.Greeks;
+	
+	    static createFrom(source: any = {}) {
+	        return new LegGreeks(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.leg_id = source["leg_id"];
+	        this.greeks = this.convertValues(source["greeks"], pricing.Greeks);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class MarketRating {
+	    id: number;
+	    overall_rating: number;
+	    sector_ratings: Record<string, number>;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketRating(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.overall_rating = source["overall_rating"];
+	        this.sector_ratings = source["sector_ratings"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarketRatingRequest {
+	    overall_rating: number;
+	    sector_ratings: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketRatingRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.overall_rating = source["overall_rating"];
+	        this.sector_ratings = source["sector_ratings"];
+	    }
+	}
+	export class MaxOpenRule {
+	    severity: string;
+	    max: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaxOpenRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	        this.max = source["max"];
+	    }
+	}
+	export class RuleViolation {
+	    rule: string;
+	    severity: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RuleViolation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule = source["rule"];
+	        this.severity = source["severity"];
+	        this.message = source["message"];
+	    }
+	}
+	export class OptionsTrade {
+	    id: number;
+	    ticker: string;
+	    sector: string;
+	    strategy_type: string;
+	    entry_date: time.Time;
+	    expiration_date: time.Time;
+	    target_price?: number;
+	    stop_loss?: number;
+	    status: string;
+	    notes: string;
+	    legs: Leg[];
+	    external_id?: string;
+	    parent_trade_id?: number;
+	    created_at: time.Time;
+	    updated_at: time.Time;
+	    warnings?: RuleViolation[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OptionsTrade(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.ticker = source["ticker"];
+	        this.sector = source["sector"];
+	        this.strategy_type = source["strategy_type"];
+	        this.entry_date = this.convertValues(source["entry_date"], time.Time);
+	        this.expiration_date = this.convertValues(source["expiration_date"], time.Time);
+	        this.target_price = source["target_price"];
+	        this.stop_loss = source["stop_loss"];
+	        this.status = source["status"];
+	        this.notes = source["notes"];
+	        this.legs = this.convertValues(source["legs"], Leg);
+	        this.external_id = source["external_id"];
+	        this.parent_trade_id = source["parent_trade_id"];
+	        this.created_at = this.convertValues(source["created_at"], time.Time);
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	        this.warnings = this.convertValues(source["warnings"], RuleViolation);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OutcomeBucket {
+	    label: string;
+	    trades: number;
+	    wins: number;
+	    losses: number;
+	    win_rate: number;
+	    realized_pnl: number;
+	    average_pnl: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutcomeBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
+	        this.trades = source["trades"];
+	        this.wins = source["wins"];
+	        this.losses = source["losses"];
+	        this.win_rate = source["win_rate"];
+	        this.realized_pnl = source["realized_pnl"];
+	        this.average_pnl = source["average_pnl"];
+	    }
+	}
+	export class TradePnL {
+	    trade_id: number;
+	    ticker: string;
+	    strategy_type: string;
+	    status: string;
+	    open_quantity: number;
+	    closed_quantity: number;
+	    remaining_quantity: number;
+	    cost_basis: number;
+	    realized_pnl: number;
+	    unrealized_pnl?: number;
+	    fees: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TradePnL(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trade_id = source["trade_id"];
+	        this.ticker = source["ticker"];
+	        this.strategy_type = source["strategy_type"];
+	        this.status = source["status"];
+	        this.open_quantity = source["open_quantity"];
+	        this.closed_quantity = source["closed_quantity"];
+	        this.remaining_quantity = source["remaining_quantity"];
+	        this.cost_basis = source["cost_basis"];
+	        this.realized_pnl = source["realized_pnl"];
+	        this.unrealized_pnl = source["unrealized_pnl"];
+	        this.fees = source["fees"];
+	    }
+	}
+	export class PnLSummary {
+	    start_date: time.Time;
+	    end_date: time.Time;
+	    trades: TradePnL[];
+	    realized_pnl: number;
+	    fees: number;
+	    winners: number;
+	    losers: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PnLSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start_date = this.convertValues(source["start_date"], time.Time);
+	        this.end_date = this.convertValues(source["end_date"], time.Time);
+	        this.trades = this.convertValues(source["trades"], TradePnL);
+	        this.realized_pnl = source["realized_pnl"];
+	        this.fees = source["fees"];
+	        this.winners = source["winners"];
+	        this.losers = source["losers"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PortfolioGreeks {
+	    as_of: time.Time;
+	    benchmark: string;
+	    benchmark_price: number;
+	    total: GreeksBucket;
+	    by_sector: GreeksBucket[];
+	    by_category: GreeksBucket[];
+	    by_expiration: GreeksBucket[];
+	    unpriced: string[];
+	    default_beta: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PortfolioGreeks(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.as_of = this.convertValues(source["as_of"], time.Time);
+	        this.benchmark = source["benchmark"];
+	        this.benchmark_price = source["benchmark_price"];
+	        this.total = this.convertValues(source["total"], GreeksBucket);
+	        this.by_sector = this.convertValues(source["by_sector"], GreeksBucket);
+	        this.by_category = this.convertValues(source["by_category"], GreeksBucket);
+	        this.by_expiration = this.convertValues(source["by_expiration"], GreeksBucket);
+	        this.unpriced = source["unpriced"];
+	        this.default_beta = source["default_beta"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Quote {
+	    price: number;
+	    volatility: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Quote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.price = source["price"];
+	        this.volatility = source["volatility"];
+	    }
+	}
+	export class PortfolioGreeksRequest {
+	    quotes: Record<string, Quote>;
+	    rate: number;
+	    benchmark_price: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PortfolioGreeksRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.quotes = this.convertValues(source["quotes"], Quote, true);
+	        this.rate = source["rate"];
+	        this.benchmark_price = source["benchmark_price"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PositionGreeks {
+	    trade_id: number;
+	    as_of: time.Time;
+	    legs: LegGreeks[];
+	    total: pricing.Greeks;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionGreeks(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trade_id = source["trade_id"];
+	        this.as_of = this.convertValues(source["as_of"], time.Time);
+	        this.legs = this.convertValues(source["legs"], LegGreeks);
+	        this.total = this.convertValues(source["total"], pricing.Greeks);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RatedTrade {
+	    trade_id: number;
+	    ticker: string;
+	    sector: string;
+	    strategy_type: string;
+	    category: string;
+	    entry_date: time.Time;
+	    market_rating_id?: number;
+	    overall_rating?: number;
+	    sector_rating?: number;
+	    rating_bucket: string;
+	    realized_pnl: number;
+	    outcome: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RatedTrade(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trade_id = source["trade_id"];
+	        this.ticker = source["ticker"];
+	        this.sector = source["sector"];
+	        this.strategy_type = source["strategy_type"];
+	        this.category = source["category"];
+	        this.entry_date = this.convertValues(source["entry_date"], time.Time);
+	        this.market_rating_id = source["market_rating_id"];
+	        this.overall_rating = source["overall_rating"];
+	        this.sector_rating = source["sector_rating"];
+	        this.rating_bucket = source["rating_bucket"];
+	        this.realized_pnl = source["realized_pnl"];
+	        this.outcome = source["outcome"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SectorRatingChange {
+	    sector_name: string;
+	    from?: number;
+	    to?: number;
+	    change: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SectorRatingChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sector_name = source["sector_name"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.change = source["change"];
+	    }
+	}
+	export class RatingComparison {
+	    from: MarketRating;
+	    to: MarketRating;
+	    overall_change: number;
+	    sector_changes: SectorRatingChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RatingComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], MarketRating);
+	        this.to = this.convertValues(source["to"], MarketRating);
+	        this.overall_change = source["overall_change"];
+	        this.sector_changes = this.convertValues(source["sector_changes"], SectorRatingChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RatingDirectionRule {
+	    severity: string;
+	    threshold: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RatingDirectionRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	        this.threshold = source["threshold"];
+	    }
+	}
+	export class ReportSummary {
+	    path: string;
+	    start_date: time.Time;
+	    end_date: time.Time;
+	    pages: number;
+	    active_trades: number;
+	    expiring: number;
+	    realized_pnl: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.start_date = this.convertValues(source["start_date"], time.Time);
+	        this.end_date = this.convertValues(source["end_date"], time.Time);
+	        this.pages = source["pages"];
+	        this.active_trades = source["active_trades"];
+	        this.expiring = source["expiring"];
+	        this.realized_pnl = source["realized_pnl"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RollChain {
+	    root_trade_id: number;
+	    trades: TradePnL[];
+	    realized_pnl: number;
+	    unrealized_pnl?: number;
+	    fees: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RollChain(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.root_trade_id = source["root_trade_id"];
+	        this.trades = this.convertValues(source["trades"], TradePnL);
+	        this.realized_pnl = source["realized_pnl"];
+	        this.unrealized_pnl = source["unrealized_pnl"];
+	        this.fees = source["fees"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RollRequest {
+	    close?: FillRequest;
+	    trade: TradeRequest;
+	    open?: FillRequest;
+	
+	    static createFrom(source: any = {}) {
+	        return new RollRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.close = this.convertValues(source["close"], FillRequest);
+	        this.trade = this.convertValues(source["trade"], TradeRequest);
+	        this.open = this.convertValues(source["open"], FillRequest);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RuleCheck {
+	    violations: RuleViolation[];
+	    blocked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RuleCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.violations = this.convertValues(source["violations"], RuleViolation);
+	        this.blocked = source["blocked"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ScenarioGrid {
+	    label: string;
+	    trades: number;
+	    pnl: number[][];
+	    worst_pnl: number;
+	    worst_price_move: number;
+	    worst_vol_shift: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioGrid(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
+	        this.trades = source["trades"];
+	        this.pnl = source["pnl"];
+	        this.worst_pnl = source["worst_pnl"];
+	        this.worst_price_move = source["worst_price_move"];
+	        this.worst_vol_shift = source["worst_vol_shift"];
+	    }
+	}
+	export class SectorShock {
+	    sector: string;
+	    price_move: number;
+	    vol_shift: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SectorShock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sector = source["sector"];
+	        this.price_move = source["price_move"];
+	        this.vol_shift = source["vol_shift"];
+	    }
+	}
+	export class ScenarioRequest {
+	    quotes: Record<string, Quote>;
+	    rate: number;
+	    price_moves: number[];
+	    vol_shifts: number[];
+	    days_forward: number;
+	    sector_shocks: SectorShock[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.quotes = this.convertValues(source["quotes"], Quote, true);
+	        this.rate = source["rate"];
+	        this.price_moves = source["price_moves"];
+	        this.vol_shifts = source["vol_shifts"];
+	        this.days_forward = source["days_forward"];
+	        this.sector_shocks = this.convertValues(source["sector_shocks"], SectorShock);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScenarioResult {
+	    as_of: time.Time;
+	    valued_at: time.Time;
+	    days_forward: number;
+	    price_moves: number[];
+	    vol_shifts: number[];
+	    sector_shocks: SectorShock[];
+	    total: ScenarioGrid;
+	    by_sector: ScenarioGrid[];
+	    worst_sector: string;
+	    unpriced: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.as_of = this.convertValues(source["as_of"], time.Time);
+	        this.valued_at = this.convertValues(source["valued_at"], time.Time);
+	        this.days_forward = source["days_forward"];
+	        this.price_moves = source["price_moves"];
+	        this.vol_shifts = source["vol_shifts"];
+	        this.sector_shocks = this.convertValues(source["sector_shocks"], SectorShock);
+	        this.total = this.convertValues(source["total"], ScenarioGrid);
+	        this.by_sector = this.convertValues(source["by_sector"], ScenarioGrid);
+	        this.worst_sector = source["worst_sector"];
+	        this.unpriced = source["unpriced"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SectorRatingPoint {
+	    market_rating_id: number;
+	    sector_name: string;
+	    rating: number;
+	    recorded_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new SectorRatingPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.market_rating_id = source["market_rating_id"];
+	        this.sector_name = source["sector_name"];
+	        this.rating = source["rating"];
+	        this.recorded_at = this.convertValues(source["recorded_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SentimentEdgeReport {
+	    start_date: time.Time;
+	    end_date: time.Time;
+	    trades: RatedTrade[];
+	    by_sector_rating: OutcomeBucket[];
+	    by_category: OutcomeBucket[];
+	    overall: OutcomeBucket;
+	
+	    static createFrom(source: any = {}) {
+	        return new SentimentEdgeReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start_date = this.convertValues(source["start_date"], time.Time);
+	        this.end_date = this.convertValues(source["end_date"], time.Time);
+	        this.trades = this.convertValues(source["trades"], RatedTrade);
+	        this.by_sector_rating = this.convertValues(source["by_sector_rating"], OutcomeBucket);
+	        this.by_category = this.convertValues(source["by_category"], OutcomeBucket);
+	        this.overall = this.convertValues(source["overall"], OutcomeBucket);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SeverityRule {
+	    severity: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SeverityRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	    }
+	}
+	export class SizingRequest {
+	    sector: string;
+	    legs: Leg[];
+	    direction: string;
+	    account_equity: number;
+	    max_risk_percent: number;
+	    volatility: number;
+	    rate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SizingRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sector = source["sector"];
+	        this.legs = this.convertValues(source["legs"], Leg);
+	        this.direction = source["direction"];
+	        this.account_equity = source["account_equity"];
+	        this.max_risk_percent = source["max_risk_percent"];
+	        this.volatility = source["volatility"];
+	        this.rate = source["rate"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SizingResult {
+	    sector: string;
+	    rating?: number;
+	    rating_source: string;
+	    direction: string;
+	    direction_inferred: boolean;
+	    account_equity: number;
+	    max_risk_percent: number;
+	    max_risk: number;
+	    scale: number;
+	    risk_budget: number;
+	    risk_per_unit: number;
+	    contracts: number;
+	    risk_used: number;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SizingResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sector = source["sector"];
+	        this.rating = source["rating"];
+	        this.rating_source = source["rating_source"];
+	        this.direction = source["direction"];
+	        this.direction_inferred = source["direction_inferred"];
+	        this.account_equity = source["account_equity"];
+	        this.max_risk_percent = source["max_risk_percent"];
+	        this.max_risk = source["max_risk"];
+	        this.scale = source["scale"];
+	        this.risk_budget = source["risk_budget"];
+	        this.risk_per_unit = source["risk_per_unit"];
+	        this.contracts = source["contracts"];
+	        this.risk_used = source["risk_used"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class StatusChange {
+	    id: number;
+	    trade_id: number;
+	    from_status: string;
+	    to_status: string;
+	    reason: string;
+	    changed_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.trade_id = source["trade_id"];
+	        this.from_status = source["from_status"];
+	        this.to_status = source["to_status"];
+	        this.reason = source["reason"];
+	        this.changed_at = this.convertValues(source["changed_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StrategyType {
+	    id: number;
+	    name: string;
+	    category: string;
+	    description: string;
+	    color_hex: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StrategyType(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.category = source["category"];
+	        this.description = source["description"];
+	        this.color_hex = source["color_hex"];
+	    }
+	}
+	export class TradePage {
+	    trades: OptionsTrade[];
+	    next_cursor?: string;
+	    has_more: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TradePage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trades = this.convertValues(source["trades"], OptionsTrade);
+	        this.next_cursor = source["next_cursor"];
+	        this.has_more = source["has_more"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TradeQuery {
+	    tickers?: string[];
+	    sectors?: string[];
+	    strategies?: string[];
+	    categories?: string[];
+	    statuses?: string[];
+	    entry_from: time.Time;
+	    entry_to: time.Time;
+	    expiration_from: time.Time;
+	    expiration_to: time.Time;
+	    active_from: time.Time;
+	    active_to: time.Time;
+	    target_price_min?: number;
+	    target_price_max?: number;
+	    stop_loss_min?: number;
+	    stop_loss_max?: number;
+	    strike_min?: number;
+	    strike_max?: number;
+	    sort_by?: string;
+	    sort_desc: boolean;
+	    limit?: number;
+	    cursor?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TradeQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tickers = source["tickers"];
+	        this.sectors = source["sectors"];
+	        this.strategies = source["strategies"];
+	        this.categories = source["categories"];
+	        this.statuses = source["statuses"];
+	        this.entry_from = this.convertValues(source["entry_from"], time.Time);
+	        this.entry_to = this.convertValues(source["entry_to"], time.Time);
+	        this.expiration_from = this.convertValues(source["expiration_from"], time.Time);
+	        this.expiration_to = this.convertValues(source["expiration_to"], time.Time);
+	        this.active_from = this.convertValues(source["active_from"], time.Time);
+	        this.active_to = this.convertValues(source["active_to"], time.Time);
+	        this.target_price_min = source["target_price_min"];
+	        this.target_price_max = source["target_price_max"];
+	        this.stop_loss_min = source["stop_loss_min"];
+	        this.stop_loss_max = source["stop_loss_max"];
+	        this.strike_min = source["strike_min"];
+	        this.strike_max = source["strike_max"];
+	        this.sort_by = source["sort_by"];
+	        this.sort_desc = source["sort_desc"];
+	        this.limit = source["limit"];
+	        this.cursor = source["cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TradeRules {
+	    max_open_per_sector: MaxOpenRule;
+	    rating_direction: RatingDirectionRule;
+	    days_to_expiration: DaysToExpirationRule;
+	    duplicate_ticker_week: SeverityRule;
+	
+	    static createFrom(source: any = {}) {
+	        return new TradeRules(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.max_open_per_sector = this.convertValues(source["max_open_per_sector"], MaxOpenRule);
+	        this.rating_direction = this.convertValues(source["rating_direction"], RatingDirectionRule);
+	        this.days_to_expiration = this.convertValues(source["days_to_expiration"], DaysToExpirationRule);
+	        this.duplicate_ticker_week = this.convertValues(source["duplicate_ticker_week"], SeverityRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TradeSearchFilters {
+	    status?: string;
+	    strategy_type?: string;
+	    sector?: string;
+	    from: time.Time;
+	    to: time.Time;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TradeSearchFilters(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.strategy_type = source["strategy_type"];
+	        this.sector = source["sector"];
+	        this.from = this.convertValues(source["from"], time.Time);
+	        this.to = this.convertValues(source["to"], time.Time);
+	        this.limit = source["limit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TradeSearchResult {
+	    trade: OptionsTrade;
+	    snippet: string;
+	    rank: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TradeSearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trade = this.convertValues(source["trade"], OptionsTrade);
+	        this.snippet = source["snippet"];
+	        this.rank = source["rank"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UnderlyingPrice {
+	    ticker: string;
+	    price: number;
+	    updated_at: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new UnderlyingPrice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ticker = source["ticker"];
+	        this.price = source["price"];
+	        this.updated_at = this.convertValues(source["updated_at"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WorkbookSummary {
+	    path: string;
+	    trades: number;
+	    legs: number;
+	    ratings: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkbookSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.trades = source["trades"];
+	        this.legs = source["legs"];
+	        this.ratings = source["ratings"];
+	    }
+	}
+
+}
+
+export namespace occ {
+	
+	export class Symbol {
+	    root: string;
+	    expiration: time.Time;
+	    option_type: string;
+	    strike: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Symbol(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.root = source["root"];
+	        this.expiration = this.convertValues(source["expiration"], time.Time);
+	        this.option_type = source["option_type"];
+	        this.strike = source["strike"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace payoff {
+	
+	export class Point {
+	    price: number;
+	    pnl: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Point(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.price = source["price"];
+	        this.pnl = source["pnl"];
+	    }
+	}
+	export class Analysis {
+	    expiration: Point[];
+	    near_term?: Point[];
+	    near_term_date?: time.Time;
+	    max_profit: number;
+	    max_profit_unlimited: boolean;
+	    max_loss: number;
+	    max_loss_unlimited: boolean;
+	    breakevens: number[];
+	    net_premium: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Analysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.expiration = this.convertValues(source["expiration"], Point);
+	        this.near_term = this.convertValues(source["near_term"], Point);
+	        this.near_term_date = this.convertValues(source["near_term_date"], time.Time);
+	        this.max_profit = source["max_profit"];
+	        this.max_profit_unlimited = source["max_profit_unlimited"];
+	        this.max_loss = source["max_loss"];
+	        this.max_loss_unlimited = source["max_loss_unlimited"];
+	        this.breakevens = source["breakevens"];
+	        this.net_premium = source["net_premium"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Options {
+	    min_price: number;
+	    max_price: number;
+	    steps: number;
+	    volatility: number;
+	    rate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.min_price = source["min_price"];
+	        this.max_price = source["max_price"];
+	        this.steps = source["steps"];
+	        this.volatility = source["volatility"];
+	        this.rate = source["rate"];
+	    }
+	}
+	
+	export class TemplateSpec {
+	    spot: number;
+	    width: number;
+	    expiration: time.Time;
+	    far_expiration: time.Time;
+	    volatility: number;
+	    rate: number;
+	    as_of: time.Time;
+	
+	    static createFrom(source: any = {}) {
+	        return new TemplateSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.spot = source["spot"];
+	        this.width = source["width"];
+	        this.expiration = this.convertValues(source["expiration"], time.Time);
+	        this.far_expiration = this.convertValues(source["far_expiration"], time.Time);
+	        this.volatility = source["volatility"];
+	        this.rate = source["rate"];
+	        this.as_of = this.convertValues(source["as_of"], time.Time);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace pricing {
+	
+	export class Greeks {
+	    price: number;
+	    delta: number;
+	    gamma: number;
+	    theta: number;
+	    vega: number;
+	    rho: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Greeks(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.price = source["price"];
+	        this.delta = source["delta"];
+	        this.gamma = source["gamma"];
+	        this.theta = source["theta"];
+	        this.vega = source["vega"];
+	        this.rho = source["rho"];
+	    }
+	}
+	export class Inputs {
+	    option_type: string;
+	    spot: number;
+	    strike: number;
+	    time_to_expiry: number;
+	    rate: number;
+	    volatility: number;
+	    dividend_yield: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Inputs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.option_type = source["option_type"];
+	        this.spot = source["spot"];
+	        this.strike = source["strike"];
+	        this.time_to_expiry = source["time_to_expiry"];
+	        this.rate = source["rate"];
+	        this.volatility = source["volatility"];
+	        this.dividend_yield = source["dividend_yield"];
+	    }
+	}
 
 }
 
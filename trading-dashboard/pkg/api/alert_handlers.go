@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+)
+
+func (s *Server) handleListAlerts(w http.ResponseWriter, r *http.Request) {
+	unread := false
+	if v := r.URL.Query().Get("unread"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid unread: %q", v))
+			return
+		}
+		unread = b
+	}
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	alerts, err := s.svc.Alerts.GetAlerts(unread, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, alerts)
+}
+
+func (s *Server) handleMarkAlertRead(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.svc.Alerts.MarkRead(id); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int64{"read": id})
+}
+
+func (s *Server) handleMarkAllAlertsRead(w http.ResponseWriter, r *http.Request) {
+	n, err := s.svc.Alerts.MarkAllRead()
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int64{"read": n})
+}
+
+func (s *Server) handleGetAlertRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := s.svc.Alerts.GetAlertRules()
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (s *Server) handleSaveAlertRules(w http.ResponseWriter, r *http.Request) {
+	var rules models.AlertRules
+	if err := decodeJSON(w, r, &rules); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.svc.Alerts.SaveAlertRules(rules); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (s *Server) handleListPrices(w http.ResponseWriter, r *http.Request) {
+	prices, err := s.svc.Alerts.GetPrices()
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, prices)
+}
+
+// priceRequest is the body of an underlying price update
+type priceRequest struct {
+	Price float64 `json:"price"`
+}
+
+func (s *Server) handleSetPrice(w http.ResponseWriter, r *http.Request) {
+	var req priceRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	ticker := strings.ToUpper(r.PathValue("ticker"))
+	if err := s.svc.Alerts.SetPrice(ticker, req.Price); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ticker": ticker, "price": req.Price})
+}
+
+func (s *Server) handleDeletePrice(w http.ResponseWriter, r *http.Request) {
+	ticker := strings.ToUpper(r.PathValue("ticker"))
+	if err := s.svc.Alerts.DeletePrice(ticker); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"deleted": ticker})
+}
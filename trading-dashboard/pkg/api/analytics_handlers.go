@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// defaultAnalyticsLookback is the entry-date window analyzed when no "from" date is given
+const defaultAnalyticsLookback = 365 * 24 * time.Hour
+
+func (s *Server) handleSentimentEdge(w http.ResponseWriter, r *http.Request) {
+	from, to, err := dateRange(r, defaultAnalyticsLookback)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	report, err := s.svc.Analytics.GetSentimentEdgeReport(from, to)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// defaultRatingLookback is the history window returned when no "from" date is given
+const defaultRatingLookback = 365 * 24 * time.Hour
+
+func (s *Server) handleGetSectors(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.svc.Market.GetSectorNames())
+}
+
+func (s *Server) handleGetLatestRating(w http.ResponseWriter, r *http.Request) {
+	rating, err := s.svc.Market.GetLatestRating()
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rating)
+}
+
+func (s *Server) handleGetRating(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rating, err := s.svc.Market.GetRatingByID(id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rating)
+}
+
+func (s *Server) handleCreateRating(w http.ResponseWriter, r *http.Request) {
+	var req models.MarketRatingRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rating, err := s.svc.Market.SaveRating(req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, rating)
+}
+
+func (s *Server) handleUpdateRating(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req models.MarketRatingRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rating, err := s.svc.Market.UpdateRating(id, req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rating)
+}
+
+func (s *Server) handleListRatings(w http.ResponseWriter, r *http.Request) {
+	from, to, err := dateRange(r, defaultRatingLookback)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	ratings, err := s.svc.Market.GetRatingsBetween(from, to)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ratings)
+}
+
+func (s *Server) handleGetSectorHistory(w http.ResponseWriter, r *http.Request) {
+	from, to, err := dateRange(r, defaultRatingLookback)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	points, err := s.svc.Market.GetSectorRatingHistory(r.PathValue("name"), from, to)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, points)
+}
+
+// handleCompareRatings compares the snapshots given by the from and to query
+// parameters, which are market rating IDs
+func (s *Server) handleCompareRatings(w http.ResponseWriter, r *http.Request) {
+	fromID, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("from must be a market rating id"))
+		return
+	}
+	toID, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("to must be a market rating id"))
+		return
+	}
+	comparison, err := s.svc.Market.CompareRatings(fromID, toID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, comparison)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxBodyBytes caps request bodies to keep a misbehaving client from
+// exhausting memory
+const maxBodyBytes = 1 << 20
+
+// pathID parses the {id} path parameter
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid id: %q", r.PathValue("id"))
+	}
+	return id, nil
+}
+
+// decodeJSON decodes the request body into v, rejecting unknown fields
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// queryDate parses a date query parameter as YYYY-MM-DD or RFC 3339,
+// returning def when the parameter is absent. With endOfDay a bare date
+// covers the whole day.
+func queryDate(r *http.Request, name string, def time.Time, endOfDay bool) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		if endOfDay {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date %q: use YYYY-MM-DD or RFC 3339", name, v)
+	}
+	return t, nil
+}
+
+// dateRange parses the from/to query parameters. "to" defaults to now and
+// "from" to the given lookback before "to".
+func dateRange(r *http.Request, lookback time.Duration) (time.Time, time.Time, error) {
+	to, err := queryDate(r, "to", time.Now(), true)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	from, err := queryDate(r, "from", to.Add(-lookback), false)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must not be after to")
+	}
+	return from, to, nil
+}
+
+// queryFloat parses an optional float query parameter
+func queryFloat(r *http.Request, name string) (*float64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return &f, nil
+}
+
+// queryInt parses an optional integer query parameter, returning 0 when absent
+func queryInt(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return n, nil
+}
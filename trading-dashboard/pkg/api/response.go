@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"trading-dashboard/pkg/services"
+)
+
+// Response is the standard envelope for every API response
+type Response struct {
+	Success   bool      `json:"success"`
+	Data      any       `json:"data"`
+	Error     *string   `json:"error"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// writeJSON writes a successful response with the given status code
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	writeResponse(w, status, Response{
+		Success:   true,
+		Data:      data,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// writeError writes a failed response with the given status code
+func writeError(w http.ResponseWriter, status int, err error) {
+	msg := err.Error()
+	writeResponse(w, status, Response{
+		Success:   false,
+		Error:     &msg,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// writeServiceError maps a service error to an HTTP status code
+func writeServiceError(w http.ResponseWriter, err error) {
+	writeError(w, statusFor(err), err)
+}
+
+// statusFor classifies service errors into HTTP status codes
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrValidation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("API: failed to encode response: %v", err)
+	}
+}
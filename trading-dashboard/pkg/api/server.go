@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"trading-dashboard/pkg/services"
+)
+
+// DefaultAddr is the loopback address the API listens on unless configured
+const DefaultAddr = "127.0.0.1:8787"
+
+// Config holds the HTTP server settings
+type Config struct {
+	Addr           string
+	AllowedOrigins []string
+}
+
+// Services holds the services exposed over HTTP
+type Services struct {
+	Market    *services.MarketService
+	Trades    *services.TradeService
+	Analytics *services.AnalyticsService
+	Alerts    *services.AlertService
+}
+
+// Server exposes the dashboard services as a JSON REST API under /api/v1/
+type Server struct {
+	svc        Services
+	httpServer *http.Server
+}
+
+// NewServer creates a new API server
+func NewServer(cfg Config, svc Services) *Server {
+	if cfg.Addr == "" {
+		cfg.Addr = DefaultAddr
+	}
+
+	s := &Server{svc: svc}
+	s.httpServer = &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           withRecovery(withLogging(withHostCheck(cfg.Addr, withCORS(cfg.AllowedOrigins, withJSONContentType(s.routes()))))),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Handler returns the server's root HTTP handler
+func (s *Server) Handler() http.Handler {
+	return s.httpServer.Handler
+}
+
+// Start binds the listen address and serves requests in the background
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.httpServer.Addr, err)
+	}
+
+	log.Printf("REST API listening on http://%s/api/v1/", ln.Addr())
+	go func() {
+		if err := s.httpServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("REST API stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Shutdown gracefully stops the server
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+// routes registers every API endpoint
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v1/health", s.handleHealth)
+
+	// Market ratings
+	mux.HandleFunc("GET /api/v1/sectors", s.handleGetSectors)
+	mux.HandleFunc("GET /api/v1/sectors/{name}/history", s.handleGetSectorHistory)
+	mux.HandleFunc("GET /api/v1/ratings", s.handleListRatings)
+	mux.HandleFunc("GET /api/v1/ratings/latest", s.handleGetLatestRating)
+	mux.HandleFunc("GET /api/v1/ratings/compare", s.handleCompareRatings)
+	mux.HandleFunc("GET /api/v1/ratings/{id}", s.handleGetRating)
+	mux.HandleFunc("POST /api/v1/ratings", s.handleCreateRating)
+	mux.HandleFunc("PUT /api/v1/ratings/{id}", s.handleUpdateRating)
+
+	// Trades
+	mux.HandleFunc("GET /api/v1/trades", s.handleListTrades)
+	mux.HandleFunc("POST /api/v1/trades", s.handleCreateTrade)
+	mux.HandleFunc("GET /api/v1/trades/search", s.handleSearchTrades)
+	mux.HandleFunc("POST /api/v1/trades/query", s.handleQueryTrades)
+	mux.HandleFunc("POST /api/v1/trades/check", s.handleCheckTrade)
+	mux.HandleFunc("GET /api/v1/trades/{id}", s.handleGetTrade)
+	mux.HandleFunc("PUT /api/v1/trades/{id}", s.handleUpdateTrade)
+	mux.HandleFunc("DELETE /api/v1/trades/{id}", s.handleDeleteTrade)
+	mux.HandleFunc("PUT /api/v1/trades/{id}/status", s.handleUpdateTradeStatus)
+	mux.HandleFunc("GET /api/v1/trades/{id}/history", s.handleGetStatusHistory)
+	mux.HandleFunc("POST /api/v1/trades/expire", s.handleExpireTrades)
+	mux.HandleFunc("GET /api/v1/trades/{id}/fills", s.handleGetFills)
+	mux.HandleFunc("POST /api/v1/trades/{id}/fills", s.handleAddFill)
+	mux.HandleFunc("POST /api/v1/trades/{id}/close", s.handleCloseTrade)
+	mux.HandleFunc("GET /api/v1/trades/{id}/pnl", s.handleGetTradePnL)
+	mux.HandleFunc("POST /api/v1/trades/{id}/roll", s.handleRollTrade)
+	mux.HandleFunc("GET /api/v1/trades/{id}/chain", s.handleGetRollChain)
+	mux.HandleFunc("GET /api/v1/pnl/realized", s.handleGetRealizedPnL)
+
+	// Analytics
+	mux.HandleFunc("GET /api/v1/analytics/sentiment-edge", s.handleSentimentEdge)
+
+	// Trade rules
+	mux.HandleFunc("GET /api/v1/trade-rules", s.handleGetTradeRules)
+	mux.HandleFunc("PUT /api/v1/trade-rules", s.handleSaveTradeRules)
+
+	// Alerts and the underlying prices they check
+	mux.HandleFunc("GET /api/v1/alerts", s.handleListAlerts)
+	mux.HandleFunc("POST /api/v1/alerts/read", s.handleMarkAllAlertsRead)
+	mux.HandleFunc("POST /api/v1/alerts/{id}/read", s.handleMarkAlertRead)
+	mux.HandleFunc("GET /api/v1/alert-rules", s.handleGetAlertRules)
+	mux.HandleFunc("PUT /api/v1/alert-rules", s.handleSaveAlertRules)
+	mux.HandleFunc("GET /api/v1/prices", s.handleListPrices)
+	mux.HandleFunc("PUT /api/v1/prices/{ticker}", s.handleSetPrice)
+	mux.HandleFunc("DELETE /api/v1/prices/{ticker}", s.handleDeletePrice)
+
+	// Strategy types
+	mux.HandleFunc("GET /api/v1/strategy-types", s.handleGetStrategyTypes)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no route for %s %s", r.Method, r.URL.Path))
+	})
+
+	return mux
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
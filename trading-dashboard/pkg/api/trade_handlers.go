@@ -0,0 +1,385 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/services"
+)
+
+// defaultTradeLookback is the window listed when no "from" date is given
+const defaultTradeLookback = 90 * 24 * time.Hour
+
+// handleListTrades lists trades entered within from/to. With status=active
+// it returns active trades overlapping the range, as the calendar grid does.
+func (s *Server) handleListTrades(w http.ResponseWriter, r *http.Request) {
+	from, to, err := dateRange(r, defaultTradeLookback)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var trades []models.OptionsTrade
+	if r.URL.Query().Get("status") == models.StatusActive {
+		trades, err = s.svc.Trades.GetActiveTradesByDateRange(from, to)
+	} else {
+		trades, err = s.svc.Trades.GetTrades(from, to)
+	}
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if trades == nil {
+		trades = []models.OptionsTrade{}
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" && status != models.StatusActive {
+		filtered := trades[:0]
+		for _, t := range trades {
+			if t.Status == status {
+				filtered = append(filtered, t)
+			}
+		}
+		trades = filtered
+	}
+
+	writeJSON(w, http.StatusOK, trades)
+}
+
+func (s *Server) handleCreateTrade(w http.ResponseWriter, r *http.Request) {
+	var req models.TradeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	trade, err := s.svc.Trades.CreateTrade(req)
+	if err != nil {
+		// A trade blocked by the rules carries the full list of violations
+		var ruleErr *services.RuleError
+		if errors.As(err, &ruleErr) {
+			msg := err.Error()
+			writeResponse(w, http.StatusBadRequest, Response{
+				Data:      models.RuleCheck{Violations: ruleErr.Violations, Blocked: true},
+				Error:     &msg,
+				Timestamp: time.Now().UTC(),
+			})
+			return
+		}
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, trade)
+}
+
+func (s *Server) handleCheckTrade(w http.ResponseWriter, r *http.Request) {
+	var req models.TradeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	check, err := s.svc.Trades.CheckTradeRules(req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, check)
+}
+
+func (s *Server) handleGetTradeRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := s.svc.Trades.GetTradeRules()
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (s *Server) handleSaveTradeRules(w http.ResponseWriter, r *http.Request) {
+	var rules models.TradeRules
+	if err := decodeJSON(w, r, &rules); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.svc.Trades.SaveTradeRules(rules); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (s *Server) handleGetTrade(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	trade, err := s.svc.Trades.GetTradeByID(id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trade)
+}
+
+func (s *Server) handleUpdateTrade(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req models.TradeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	trade, err := s.svc.Trades.UpdateTrade(id, req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trade)
+}
+
+func (s *Server) handleDeleteTrade(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.svc.Trades.DeleteTrade(id); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int64{"deleted": id})
+}
+
+// statusRequest is the body of a trade status update
+type statusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+func (s *Server) handleUpdateTradeStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req statusRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	trade, err := s.svc.Trades.TransitionTrade(id, req.Status, req.Reason)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trade)
+}
+
+func (s *Server) handleGetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	history, err := s.svc.Trades.GetStatusHistory(id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+func (s *Server) handleExpireTrades(w http.ResponseWriter, r *http.Request) {
+	changes, err := s.svc.Trades.ExpireOverdueTrades(time.Now())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, changes)
+}
+
+func (s *Server) handleGetFills(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	fills, err := s.svc.Trades.GetFills(id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, fills)
+}
+
+func (s *Server) handleAddFill(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req models.FillRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	fill, err := s.svc.Trades.AddFill(id, req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, fill)
+}
+
+func (s *Server) handleCloseTrade(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req models.FillRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	trade, err := s.svc.Trades.CloseTrade(id, req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trade)
+}
+
+func (s *Server) handleGetTradePnL(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	mark, err := queryFloat(r, "mark")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	pnl, err := s.svc.Trades.GetTradePnL(id, mark)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pnl)
+}
+
+func (s *Server) handleRollTrade(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req models.RollRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	trade, err := s.svc.Trades.RollTrade(id, req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, trade)
+}
+
+func (s *Server) handleGetRollChain(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	mark, err := queryFloat(r, "mark")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	chain, err := s.svc.Trades.GetRollChain(id, mark)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, chain)
+}
+
+// handleQueryTrades returns one page of trades for a TradeQuery body
+func (s *Server) handleQueryTrades(w http.ResponseWriter, r *http.Request) {
+	var query models.TradeQuery
+	if err := decodeJSON(w, r, &query); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	page, err := s.svc.Trades.QueryTrades(query)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// handleSearchTrades searches the whole trade history. q is required;
+// status, strategy, sector, from, to and limit narrow the results.
+func (s *Server) handleSearchTrades(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filters := models.TradeSearchFilters{
+		Status:       q.Get("status"),
+		StrategyType: q.Get("strategy"),
+		Sector:       q.Get("sector"),
+	}
+	var err error
+	if filters.From, err = queryDate(r, "from", time.Time{}, false); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filters.To, err = queryDate(r, "to", time.Time{}, true); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filters.Limit, err = queryInt(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	results, err := s.svc.Trades.SearchTrades(q.Get("q"), filters)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) handleGetRealizedPnL(w http.ResponseWriter, r *http.Request) {
+	from, to, err := dateRange(r, defaultTradeLookback)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	summary, err := s.svc.Trades.GetRealizedPnL(from, to)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func (s *Server) handleGetStrategyTypes(w http.ResponseWriter, r *http.Request) {
+	strategies, err := s.svc.Trades.GetStrategyTypes()
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if strategies == nil {
+		strategies = []models.StrategyType{}
+	}
+	writeJSON(w, http.StatusOK, strategies)
+}
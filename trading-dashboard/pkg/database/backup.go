@@ -0,0 +1,129 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// BackupTo writes a consistent snapshot of the database to path with
+// VACUUM INTO. The snapshot is written beside path and renamed into place, so
+// path only ever holds a complete backup.
+func (db *DB) BackupTo(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	partial := path + ".partial"
+	// VACUUM INTO refuses to overwrite, so clear a leftover from a crash
+	os.Remove(partial)
+	if _, err := db.Exec("VACUUM INTO ?", partial); err != nil {
+		os.Remove(partial)
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(partial, path); err != nil {
+		os.Remove(partial)
+		return fmt.Errorf("failed to finalize backup: %w", err)
+	}
+	return nil
+}
+
+// CheckDatabaseFile opens a database file without writing to it and
+// verifies that it passes SQLite's integrity check and was written by a
+// schema version this application can open. It returns that version.
+func CheckDatabaseFile(path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("failed to open database file: %w", err)
+	}
+
+	conn, err := sql.Open("sqlite3", path+"?_query_only=1")
+	if err != nil {
+		return 0, fmt.Errorf("failed to open database file: %w", err)
+	}
+	defer conn.Close()
+
+	var result string
+	if err := conn.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return 0, fmt.Errorf("failed to check database integrity: %w", err)
+	}
+	if result != "ok" {
+		return 0, fmt.Errorf("database failed integrity check: %s", result)
+	}
+
+	var tables int
+	err = conn.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name IN ('options_trades', 'market_ratings')
+	`).Scan(&tables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read database tables: %w", err)
+	}
+	if tables < 2 {
+		return 0, fmt.Errorf("not a Trading Dashboard database")
+	}
+
+	// Databases created before versioning have no schema_migrations table;
+	// Migrate upgrades them on open
+	version := 0
+	err = conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'").Scan(&tables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if tables > 0 {
+		if err := conn.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+			return 0, fmt.Errorf("failed to read schema version: %w", err)
+		}
+	}
+	if version > LatestVersion() {
+		return 0, fmt.Errorf("%w: database is at version %d, latest known is %d", ErrSchemaTooNew, version, LatestVersion())
+	}
+
+	return version, nil
+}
+
+// ReplaceDatabaseFile copies src over the database at dbPath. The copy is
+// synced to disk before it is renamed into place, so a crash leaves either
+// the old database or the new one. The database must be closed.
+func ReplaceDatabaseFile(dbPath, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer in.Close()
+
+	staged := dbPath + ".restore"
+	out, err := os.Create(staged)
+	if err != nil {
+		return fmt.Errorf("failed to stage restore: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(staged)
+		return fmt.Errorf("failed to copy backup: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(staged)
+		return fmt.Errorf("failed to sync restored database: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(staged)
+		return fmt.Errorf("failed to stage restore: %w", err)
+	}
+
+	// A journal left beside the old database would be replayed into the new one
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+			os.Remove(staged)
+			return fmt.Errorf("failed to remove %s file: %w", suffix, err)
+		}
+	}
+
+	if err := os.Rename(staged, dbPath); err != nil {
+		os.Remove(staged)
+		return fmt.Errorf("failed to swap in restored database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,341 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Migration is a numbered, forward-only schema change
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// ErrSchemaTooNew is returned when the database was migrated by a newer
+// version of the application than this one
+var ErrSchemaTooNew = errors.New("database schema is newer than this application supports")
+
+// migrations lists every schema change in order. Never edit an applied
+// migration; append a new one instead. The first migrations use IF NOT EXISTS
+// so they also apply cleanly to databases created before versioning existed.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "initial schema",
+		SQL: `-- Market sentiment database schema
+-- This stores overall market ratings and sector-specific ratings
+
+CREATE TABLE IF NOT EXISTS market_ratings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    overall_rating REAL CHECK (overall_rating >= -3 AND overall_rating <= 3),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS sector_ratings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    market_rating_id INTEGER,
+    sector_name TEXT NOT NULL,
+    rating REAL CHECK (rating >= -3 AND rating <= 3),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (market_rating_id) REFERENCES market_ratings(id) ON DELETE CASCADE
+);
+
+-- Index for faster queries
+CREATE INDEX IF NOT EXISTS idx_market_ratings_created_at ON market_ratings(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_sector_ratings_market_id ON sector_ratings(market_rating_id);
+CREATE INDEX IF NOT EXISTS idx_sector_ratings_sector ON sector_ratings(sector_name);
+
+-- Trigger to update the updated_at timestamp
+CREATE TRIGGER IF NOT EXISTS update_market_ratings_timestamp 
+    AFTER UPDATE ON market_ratings
+BEGIN
+    UPDATE market_ratings SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+-- Options trading tables
+CREATE TABLE IF NOT EXISTS options_trades (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    ticker TEXT NOT NULL,
+    sector TEXT NOT NULL,
+    strategy_type TEXT NOT NULL,
+    entry_date DATE NOT NULL,
+    expiration_date DATE NOT NULL,
+    target_price DECIMAL(10,2),
+    stop_loss DECIMAL(10,2),
+    status TEXT DEFAULT 'active' CHECK (status IN ('active', 'closed', 'expired')),
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Strategy definitions table
+CREATE TABLE IF NOT EXISTS strategy_types (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    category TEXT NOT NULL,
+    description TEXT,
+    color_hex TEXT DEFAULT '#4a90e2'
+);
+
+-- Indexes for trades table
+CREATE INDEX IF NOT EXISTS idx_trades_ticker ON options_trades(ticker);
+CREATE INDEX IF NOT EXISTS idx_trades_sector ON options_trades(sector);
+CREATE INDEX IF NOT EXISTS idx_trades_status ON options_trades(status);
+CREATE INDEX IF NOT EXISTS idx_trades_entry_date ON options_trades(entry_date);
+CREATE INDEX IF NOT EXISTS idx_trades_expiration_date ON options_trades(expiration_date);
+CREATE INDEX IF NOT EXISTS idx_trades_strategy ON options_trades(strategy_type);
+
+-- Trigger to update trades updated_at timestamp
+CREATE TRIGGER IF NOT EXISTS update_trades_timestamp 
+    AFTER UPDATE ON options_trades
+BEGIN
+    UPDATE options_trades SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;`,
+	},
+	{
+		Version: 2,
+		Name:    "trade legs",
+		SQL: `-- Option legs belonging to a trade (one row per strike/expiration/side)
+CREATE TABLE IF NOT EXISTS trade_legs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    trade_id INTEGER NOT NULL,
+    option_type TEXT NOT NULL CHECK (option_type IN ('call', 'put', 'stock')),
+    side TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    strike DECIMAL(10,2) NOT NULL DEFAULT 0,
+    expiration_date DATE NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    premium DECIMAL(10,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_trade_legs_trade_id ON trade_legs(trade_id);`,
+	},
+	{
+		Version: 3,
+		Name:    "trade fills",
+		SQL: `-- Opening and closing transactions recorded against a trade
+CREATE TABLE IF NOT EXISTS trade_fills (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    trade_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('open', 'close')),
+    side TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    fees DECIMAL(10,2) NOT NULL DEFAULT 0,
+    filled_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_trade_fills_trade_id ON trade_fills(trade_id);
+CREATE INDEX IF NOT EXISTS idx_trade_fills_filled_at ON trade_fills(filled_at);`,
+	},
+	{
+		Version: 4,
+		Name:    "broker import ids",
+		SQL: `-- Broker order/execution identifiers used to de-duplicate imports
+ALTER TABLE options_trades ADD COLUMN external_id TEXT;
+ALTER TABLE trade_fills ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX idx_trades_external_id ON options_trades(external_id) WHERE external_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_trade_fills_external_id ON trade_fills(external_id) WHERE external_id IS NOT NULL;`,
+	},
+	{
+		Version: 5,
+		Name:    "trade status history",
+		SQL: `-- Every status transition of a trade, manual or automatic
+CREATE TABLE trade_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    trade_id INTEGER NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_trade_status_history_trade_id ON trade_status_history(trade_id);`,
+	},
+	{
+		Version: 6,
+		Name:    "trade lifecycle statuses",
+		SQL: `-- SQLite cannot alter a CHECK constraint, so options_trades is rebuilt with
+-- the lifecycle statuses. Foreign keys are not enforced on this connection,
+-- so dropping the old table leaves legs, fills and history intact.
+CREATE TABLE options_trades_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    ticker TEXT NOT NULL,
+    sector TEXT NOT NULL,
+    strategy_type TEXT NOT NULL,
+    entry_date DATE NOT NULL,
+    expiration_date DATE NOT NULL,
+    target_price DECIMAL(10,2),
+    stop_loss DECIMAL(10,2),
+    status TEXT DEFAULT 'active' CHECK (status IN ('planned', 'active', 'adjusted', 'rolled', 'assigned', 'exercised', 'closed', 'expired')),
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    external_id TEXT
+);
+
+INSERT INTO options_trades_new (
+    id, ticker, sector, strategy_type, entry_date, expiration_date,
+    target_price, stop_loss, status, notes, created_at, updated_at, external_id
+)
+SELECT id, ticker, sector, strategy_type, entry_date, expiration_date,
+       target_price, stop_loss, status, notes, created_at, updated_at, external_id
+FROM options_trades;
+
+-- Keep AUTOINCREMENT from reusing the IDs of deleted trades
+UPDATE sqlite_sequence
+SET seq = MAX(seq, COALESCE((SELECT seq FROM sqlite_sequence WHERE name = 'options_trades'), 0))
+WHERE name = 'options_trades_new';
+
+DROP TABLE options_trades;
+ALTER TABLE options_trades_new RENAME TO options_trades;
+
+CREATE INDEX idx_trades_ticker ON options_trades(ticker);
+CREATE INDEX idx_trades_sector ON options_trades(sector);
+CREATE INDEX idx_trades_status ON options_trades(status);
+CREATE INDEX idx_trades_entry_date ON options_trades(entry_date);
+CREATE INDEX idx_trades_expiration_date ON options_trades(expiration_date);
+CREATE INDEX idx_trades_strategy ON options_trades(strategy_type);
+CREATE UNIQUE INDEX idx_trades_external_id ON options_trades(external_id) WHERE external_id IS NOT NULL;
+
+CREATE TRIGGER update_trades_timestamp
+    AFTER UPDATE ON options_trades
+BEGIN
+    UPDATE options_trades SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;`,
+	},
+	{
+		Version: 7,
+		Name:    "trade roll chains",
+		SQL: `-- A rolled trade points at the trade it was rolled out of
+ALTER TABLE options_trades ADD COLUMN parent_trade_id INTEGER REFERENCES options_trades(id);
+
+CREATE INDEX idx_trades_parent_trade_id ON options_trades(parent_trade_id);`,
+	},
+	{
+		Version: 8,
+		Name:    "settings",
+		SQL: `-- Application settings as key/value pairs
+CREATE TABLE settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`,
+	},
+	{
+		Version: 9,
+		Name:    "betas",
+		SQL: `-- Beta of each underlying against SPY, for beta-weighted delta
+CREATE TABLE betas (
+    ticker TEXT PRIMARY KEY,
+    beta REAL NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`,
+	},
+	{
+		Version: 10,
+		Name:    "alerts",
+		SQL: `-- Latest known price of each underlying, checked against targets and stops
+CREATE TABLE underlying_prices (
+    ticker TEXT PRIMARY KEY,
+    price REAL NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Alert inbox. dedupe_key stops a condition from alerting more than once.
+CREATE TABLE alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule TEXT NOT NULL,
+    trade_id INTEGER,
+    ticker TEXT NOT NULL DEFAULT '',
+    sector TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    dedupe_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_alerts_trade_id ON alerts(trade_id);
+CREATE INDEX idx_alerts_read_at ON alerts(read_at);`,
+	},
+}
+
+const createMigrationsTableSQL = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`
+
+// LatestVersion returns the schema version this application migrates to
+func LatestVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the highest migration version applied to the database
+func (db *DB) SchemaVersion() (int, error) {
+	if _, err := db.Exec(createMigrationsTableSQL); err != nil {
+		return 0, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var version int
+	err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Migrate applies all pending migrations, each in its own transaction. It
+// refuses to run against a database from a newer application version.
+func (db *DB) Migrate() error {
+	current, err := db.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if current > LatestVersion() {
+		return fmt.Errorf("%w: database is at version %d, latest known is %d", ErrSchemaTooNew, current, LatestVersion())
+	}
+
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		log.Printf("Applying migration %d: %s", m.Version, m.Name)
+		if err := db.applyMigration(m); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration and records it atomically
+func (db *DB) applyMigration(m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.SQL); err != nil {
+		return fmt.Errorf("failed to execute migration SQL: %w", err)
+	}
+
+	_, err = tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.Version, m.Name)
+	if err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -1,5 +1,13 @@
 -- Market sentiment database schema
 -- This stores overall market ratings and sector-specific ratings
+-- Reference copy of the current schema; changes are applied through
+-- the numbered migrations in migrations.go
+
+CREATE TABLE schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
 
 CREATE TABLE market_ratings (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -39,10 +47,12 @@ CREATE TABLE options_trades (
     expiration_date DATE NOT NULL,
     target_price DECIMAL(10,2),
     stop_loss DECIMAL(10,2),
-    status TEXT DEFAULT 'active' CHECK (status IN ('active', 'closed', 'expired')),
+    status TEXT DEFAULT 'active' CHECK (status IN ('planned', 'active', 'adjusted', 'rolled', 'assigned', 'exercised', 'closed', 'expired')),
     notes TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    external_id TEXT,
+    parent_trade_id INTEGER REFERENCES options_trades(id)
 );
 
 -- Strategy definitions table
@@ -103,10 +113,100 @@ CREATE INDEX idx_trades_status ON options_trades(status);
 CREATE INDEX idx_trades_entry_date ON options_trades(entry_date);
 CREATE INDEX idx_trades_expiration_date ON options_trades(expiration_date);
 CREATE INDEX idx_trades_strategy ON options_trades(strategy_type);
+CREATE INDEX idx_trades_parent_trade_id ON options_trades(parent_trade_id);
 
 -- Trigger to update trades updated_at timestamp
 CREATE TRIGGER update_trades_timestamp 
     AFTER UPDATE ON options_trades
 BEGIN
     UPDATE options_trades SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
-END; 
\ No newline at end of file
+END; 
+
+-- Option legs belonging to a trade (one row per strike/expiration/side)
+CREATE TABLE trade_legs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    trade_id INTEGER NOT NULL,
+    option_type TEXT NOT NULL CHECK (option_type IN ('call', 'put', 'stock')),
+    side TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    strike DECIMAL(10,2) NOT NULL DEFAULT 0,
+    expiration_date DATE NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    premium DECIMAL(10,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_trade_legs_trade_id ON trade_legs(trade_id);
+
+-- Opening and closing transactions recorded against a trade
+CREATE TABLE trade_fills (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    trade_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('open', 'close')),
+    side TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    fees DECIMAL(10,2) NOT NULL DEFAULT 0,
+    filled_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    external_id TEXT,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_trade_fills_trade_id ON trade_fills(trade_id);
+CREATE INDEX idx_trade_fills_filled_at ON trade_fills(filled_at);
+
+-- Broker order/execution identifiers used to de-duplicate imports
+CREATE UNIQUE INDEX idx_trades_external_id ON options_trades(external_id) WHERE external_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_trade_fills_external_id ON trade_fills(external_id) WHERE external_id IS NOT NULL;
+
+-- Every status transition of a trade, manual or automatic
+CREATE TABLE trade_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    trade_id INTEGER NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_trade_status_history_trade_id ON trade_status_history(trade_id);
+
+-- Application settings as key/value pairs
+CREATE TABLE settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Beta of each underlying against SPY, for beta-weighted delta
+CREATE TABLE betas (
+    ticker TEXT PRIMARY KEY,
+    beta REAL NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Latest known price of each underlying, checked against targets and stops
+CREATE TABLE underlying_prices (
+    ticker TEXT PRIMARY KEY,
+    price REAL NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Alert inbox. dedupe_key stops a condition from alerting more than once.
+CREATE TABLE alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule TEXT NOT NULL,
+    trade_id INTEGER,
+    ticker TEXT NOT NULL DEFAULT '',
+    sector TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    dedupe_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP,
+    FOREIGN KEY (trade_id) REFERENCES options_trades(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_alerts_trade_id ON alerts(trade_id);
+CREATE INDEX idx_alerts_read_at ON alerts(read_at);
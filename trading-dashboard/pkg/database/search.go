@@ -0,0 +1,101 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// searchTriggerNames are the triggers created from searchTriggers
+var searchTriggerNames = []string{"trades_fts_insert", "trades_fts_delete", "trades_fts_update"}
+
+// searchTriggers keep trades_fts in step with options_trades. The index uses
+// external content, so deletes must repeat the indexed values.
+var searchTriggers = []string{
+	`CREATE TRIGGER trades_fts_insert AFTER INSERT ON options_trades BEGIN
+    INSERT INTO trades_fts (rowid, ticker, sector, strategy_type, notes)
+    VALUES (new.id, new.ticker, new.sector, new.strategy_type, new.notes);
+END`,
+	`CREATE TRIGGER trades_fts_delete AFTER DELETE ON options_trades BEGIN
+    INSERT INTO trades_fts (trades_fts, rowid, ticker, sector, strategy_type, notes)
+    VALUES ('delete', old.id, old.ticker, old.sector, old.strategy_type, old.notes);
+END`,
+	`CREATE TRIGGER trades_fts_update AFTER UPDATE ON options_trades BEGIN
+    INSERT INTO trades_fts (trades_fts, rowid, ticker, sector, strategy_type, notes)
+    VALUES ('delete', old.id, old.ticker, old.sector, old.strategy_type, old.notes);
+    INSERT INTO trades_fts (rowid, ticker, sector, strategy_type, notes)
+    VALUES (new.id, new.ticker, new.sector, new.strategy_type, new.notes);
+END`,
+}
+
+// EnsureSearchIndex sets up the FTS5 index over trade tickers and notes and
+// reports whether full-text search is available. FTS5 is only compiled in
+// with the sqlite_fts5 build tag. Without it the sync triggers are dropped,
+// so a database indexed by another build stays writable, and the index is
+// rebuilt the next time an FTS5 build opens it.
+func (db *DB) EnsureSearchIndex() (bool, error) {
+	// CREATE ... IF NOT EXISTS succeeds without FTS5 once another build has
+	// created the table, so ask the library directly
+	var enabled bool
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		return false, fmt.Errorf("failed to check for FTS5: %w", err)
+	}
+	if !enabled {
+		return false, dropSearchTriggers(db)
+	}
+
+	_, err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS trades_fts USING fts5(
+    ticker, sector, strategy_type, notes,
+    content = 'options_trades', content_rowid = 'id'
+)`)
+	if err != nil {
+		return false, fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	var triggers int
+	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN (?, ?, ?)",
+		searchTriggerNames[0], searchTriggerNames[1], searchTriggerNames[2]).Scan(&triggers)
+	if err != nil {
+		return false, fmt.Errorf("failed to check search triggers: %w", err)
+	}
+	if triggers == len(searchTriggers) {
+		return true, nil
+	}
+
+	// The index is new or was left stale by a build without FTS5
+	log.Printf("Rebuilding trade search index")
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := dropSearchTriggers(tx); err != nil {
+		return false, err
+	}
+	for _, trigger := range searchTriggers {
+		if _, err := tx.Exec(trigger); err != nil {
+			return false, fmt.Errorf("failed to create search trigger: %w", err)
+		}
+	}
+	if _, err := tx.Exec("INSERT INTO trades_fts (trades_fts) VALUES ('rebuild')"); err != nil {
+		return false, fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// dropSearchTriggers removes the index sync triggers if they exist
+func dropSearchTriggers(db interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}) error {
+	for _, name := range searchTriggerNames {
+		if _, err := db.Exec("DROP TRIGGER IF EXISTS " + name); err != nil {
+			return fmt.Errorf("failed to drop search trigger %s: %w", name, err)
+		}
+	}
+	return nil
+}
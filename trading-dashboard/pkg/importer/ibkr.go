@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+)
+
+// ibkrParser reads the Trades section of an Interactive Brokers Flex Query
+// XML report. Only execution-level rows are used; order and symbol summary
+// rows are ignored so executions are not counted twice.
+type ibkrParser struct{}
+
+// flexTrade holds the attributes of a Flex <Trade> element the importer uses
+type flexTrade struct {
+	AssetCategory      string `xml:"assetCategory,attr"`
+	Symbol             string `xml:"symbol,attr"`
+	UnderlyingSymbol   string `xml:"underlyingSymbol,attr"`
+	PutCall            string `xml:"putCall,attr"`
+	Strike             string `xml:"strike,attr"`
+	Expiry             string `xml:"expiry,attr"`
+	DateTime           string `xml:"dateTime,attr"`
+	TradeDate          string `xml:"tradeDate,attr"`
+	Quantity           string `xml:"quantity,attr"`
+	TradePrice         string `xml:"tradePrice,attr"`
+	IBCommission       string `xml:"ibCommission,attr"`
+	BuySell            string `xml:"buySell,attr"`
+	OpenCloseIndicator string `xml:"openCloseIndicator,attr"`
+	TradeID            string `xml:"tradeID,attr"`
+	IBOrderID          string `xml:"ibOrderID,attr"`
+	LevelOfDetail      string `xml:"levelOfDetail,attr"`
+}
+
+func (ibkrParser) Broker() string { return BrokerIBKR }
+
+func (ibkrParser) Parse(r io.Reader) ([]Execution, []string, error) {
+	decoder := xml.NewDecoder(r)
+
+	var (
+		execs   []Execution
+		skipped []string
+		found   bool
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid Flex XML: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "FlexQueryResponse":
+			found = true
+			continue
+		case "Trade":
+		default:
+			continue
+		}
+
+		var t flexTrade
+		if err := decoder.DecodeElement(&t, &start); err != nil {
+			return nil, nil, fmt.Errorf("invalid Trade element: %w", err)
+		}
+		if t.LevelOfDetail != "" && !strings.EqualFold(t.LevelOfDetail, "EXECUTION") {
+			continue
+		}
+
+		e, reason, err := t.execution()
+		if err != nil {
+			return nil, nil, fmt.Errorf("trade %s: %w", t.TradeID, err)
+		}
+		if reason != "" {
+			skipped = append(skipped, fmt.Sprintf("trade %s: %s", t.TradeID, reason))
+			continue
+		}
+		execs = append(execs, e)
+	}
+
+	if !found {
+		return nil, nil, fmt.Errorf("not a Flex Query report")
+	}
+	return execs, skipped, nil
+}
+
+// execution converts a Flex trade, returning a skip reason for rows the
+// importer does not handle
+func (t flexTrade) execution() (Execution, string, error) {
+	var e Execution
+
+	switch strings.ToUpper(t.AssetCategory) {
+	case "OPT":
+		optionType, ok := parseOptionType(t.PutCall)
+		if !ok || optionType == models.OptionTypeStock {
+			return e, "", fmt.Errorf("invalid putCall %q", t.PutCall)
+		}
+		e.OptionType = optionType
+		e.Ticker = t.UnderlyingSymbol
+		strike, err := parseNumber(t.Strike)
+		if err != nil {
+			return e, "", err
+		}
+		e.Strike = strike
+		if e.Expiration, err = parseDate(t.Expiry, "20060102", "2006-01-02"); err != nil {
+			return e, "", err
+		}
+	case "STK":
+		e.OptionType = models.OptionTypeStock
+		e.Ticker = t.Symbol
+	default:
+		return e, fmt.Sprintf("unsupported asset category %q", t.AssetCategory), nil
+	}
+	e.Ticker = strings.ToUpper(strings.TrimSpace(e.Ticker))
+
+	// openCloseIndicator is "O", "C" or "C;O" when a fill both closes and
+	// reopens; the latter is treated as opening
+	indicator := strings.ToUpper(t.OpenCloseIndicator)
+	switch {
+	case strings.Contains(indicator, "O"):
+		e.Opening = true
+	case strings.Contains(indicator, "C"):
+	default:
+		return e, fmt.Sprintf("unknown open/close indicator %q", t.OpenCloseIndicator), nil
+	}
+
+	e.Side = strings.ToLower(t.BuySell)
+	if e.Side != models.SideBuy && e.Side != models.SideSell {
+		return e, "", fmt.Errorf("invalid buySell %q", t.BuySell)
+	}
+
+	var err error
+	if e.Quantity, err = parseQuantity(t.Quantity); err != nil {
+		return e, "", err
+	}
+	if e.Price, err = parseNumber(t.TradePrice); err != nil {
+		return e, "", err
+	}
+	commission, err := parseNumber(t.IBCommission)
+	if err != nil {
+		return e, "", err
+	}
+	e.Fees = math.Abs(commission)
+
+	stamp := t.DateTime
+	if stamp == "" {
+		stamp = t.TradeDate
+	}
+	if e.ExecutedAt, err = parseMarketTime(stamp, "20060102;150405", "20060102 150405", "2006-01-02;15:04:05", "2006-01-02, 15:04:05", "20060102"); err != nil {
+		return e, "", err
+	}
+
+	e.OrderID = t.IBOrderID
+	if e.OrderID == "" {
+		e.OrderID = t.TradeID
+	}
+	return e, "", nil
+}
@@ -0,0 +1,515 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// Supported brokers
+const (
+	BrokerThinkorswim = "thinkorswim"
+	BrokerIBKR        = "ibkr"
+	BrokerTastytrade  = "tastytrade"
+)
+
+// DefaultSector is assigned to imported tickers with no earlier trade to
+// copy a sector from
+const DefaultSector = "Unassigned"
+
+// UnclassifiedStrategy is used when the leg structure matches no seeded
+// strategy type
+const UnclassifiedStrategy = "Unclassified"
+
+// Execution is a single broker execution normalized across brokers. Price is
+// per share and Quantity is unsigned (contracts for options, shares for stock).
+type Execution struct {
+	OrderID    string
+	Ticker     string
+	OptionType string
+	Side       string
+	Opening    bool
+	Strike     float64
+	Expiration time.Time
+	Quantity   int
+	Price      float64
+	Fees       float64
+	ExecutedAt time.Time
+}
+
+// Parser reads a broker statement into executions. Rows that are not option
+// or stock trades are reported as skipped rather than failing the parse.
+type Parser interface {
+	Broker() string
+	Parse(r io.Reader) (execs []Execution, skipped []string, err error)
+}
+
+// Lookup is the subset of the trade service the importer needs to match
+// closing orders and de-duplicate against existing rows
+type Lookup interface {
+	TradeExternalIDExists(externalID string) (bool, error)
+	FillExternalIDExists(externalID string) (bool, error)
+	GetOpenTrades() ([]models.OptionsTrade, error)
+	LatestSectorForTicker(ticker string) (string, error)
+}
+
+// Options controls how imported trades are labeled
+type Options struct {
+	// DefaultSector overrides DefaultSector for tickers never traded before
+	DefaultSector string `json:"default_sector"`
+}
+
+// Brokers returns the supported broker identifiers
+func Brokers() []string {
+	return []string{BrokerThinkorswim, BrokerIBKR, BrokerTastytrade}
+}
+
+// ParserFor returns the parser for a broker identifier
+func ParserFor(broker string) (Parser, error) {
+	switch strings.ToLower(broker) {
+	case BrokerThinkorswim, "tos":
+		return thinkorswimParser{}, nil
+	case BrokerIBKR, "ib", "interactivebrokers":
+		return ibkrParser{}, nil
+	case BrokerTastytrade, "tasty", "tastyworks":
+		return tastytradeParser{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported broker: %s", broker)
+	}
+}
+
+// Preview parses a statement and plans the trades and closing fills it
+// contains without writing anything. Opening orders become trades with legs
+// and an opening fill; closing orders are matched to the open trade, from
+// this statement or the database, that holds the same contracts.
+func Preview(p Parser, r io.Reader, lookup Lookup, opts Options) (*models.ImportPreview, error) {
+	execs, skipped, err := p.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s statement: %w", p.Broker(), err)
+	}
+
+	open, err := lookup.GetOpenTrades()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load open trades: %w", err)
+	}
+
+	pl := &planner{
+		broker:  p.Broker(),
+		lookup:  lookup,
+		opts:    opts,
+		sectors: make(map[string]string),
+		preview: &models.ImportPreview{
+			Broker:  p.Broker(),
+			Trades:  []models.ImportedTrade{},
+			Closes:  []models.ImportedClose{},
+			Skipped: append([]string{}, skipped...),
+		},
+	}
+	for _, trade := range open {
+		pl.positions = append(pl.positions, newPosition(trade.Ticker, trade.Legs, trade.ID, ""))
+	}
+
+	for _, o := range groupOrders(execs) {
+		if err := pl.plan(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return pl.preview, nil
+}
+
+// order is the set of executions sharing a broker order ID
+type order struct {
+	id         string
+	ticker     string
+	executedAt time.Time
+	execs      []Execution
+}
+
+// groupOrders groups executions by order and sorts the orders by time
+func groupOrders(execs []Execution) []*order {
+	byID := make(map[string]*order)
+	var orders []*order
+	for _, e := range execs {
+		key := e.Ticker + "|" + e.OrderID
+		o, ok := byID[key]
+		if !ok {
+			o = &order{id: e.OrderID, ticker: e.Ticker, executedAt: e.ExecutedAt}
+			byID[key] = o
+			orders = append(orders, o)
+		}
+		if e.ExecutedAt.Before(o.executedAt) {
+			o.executedAt = e.ExecutedAt
+		}
+		o.execs = append(o.execs, e)
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].executedAt.Before(orders[j].executedAt)
+	})
+	return orders
+}
+
+// contract identifies an option series (or the stock) within a ticker
+type contract struct {
+	optionType string
+	strike     float64
+	expiration string
+}
+
+func contractOf(optionType string, strike float64, expiration time.Time) contract {
+	if optionType == models.OptionTypeStock {
+		return contract{optionType: optionType}
+	}
+	return contract{optionType: optionType, strike: strike, expiration: expiration.Format("2006-01-02")}
+}
+
+// position is an open trade that later closing orders can match. held
+// counts the contracts of each leg not yet closed by this import.
+type position struct {
+	tradeID    int64
+	externalID string
+	ticker     string
+	held       map[contract]heldLeg
+}
+
+// heldLeg is the open side and quantity of one contract in a position
+type heldLeg struct {
+	side     string
+	quantity int
+}
+
+func newPosition(ticker string, legs []models.Leg, tradeID int64, externalID string) *position {
+	p := &position{tradeID: tradeID, externalID: externalID, ticker: ticker, held: make(map[contract]heldLeg)}
+	for _, leg := range legs {
+		p.held[contractOf(leg.OptionType, leg.Strike, leg.ExpirationDate)] = heldLeg{side: leg.Side, quantity: leg.Quantity}
+	}
+	return p
+}
+
+// closedBy reports whether every leg of a closing order offsets contracts
+// the position still holds
+func (p *position) closedBy(ticker string, legs []models.Leg) bool {
+	if p.ticker != ticker || len(p.held) == 0 {
+		return false
+	}
+	for _, leg := range legs {
+		h, ok := p.held[contractOf(leg.OptionType, leg.Strike, leg.ExpirationDate)]
+		if !ok || h.side == leg.Side || h.quantity < leg.Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// closesWhole reports whether a closing order that closedBy accepts covers
+// every contract the position holds at the ratios it holds them, so it closes
+// whole units rather than adjusting some legs
+func (p *position) closesWhole(legs []models.Leg) bool {
+	if len(legs) != len(p.held) {
+		return false
+	}
+	unit := 0
+	for _, h := range p.held {
+		unit = gcd(unit, h.quantity)
+	}
+	units := 0
+	for _, leg := range legs {
+		ratio := p.held[contractOf(leg.OptionType, leg.Strike, leg.ExpirationDate)].quantity / unit
+		if leg.Quantity%ratio != 0 || (units != 0 && leg.Quantity/ratio != units) {
+			return false
+		}
+		units = leg.Quantity / ratio
+	}
+	return true
+}
+
+// consume removes the contracts closed by an order, so a position that is
+// used up matches nothing further
+func (p *position) consume(legs []models.Leg) {
+	for _, leg := range legs {
+		c := contractOf(leg.OptionType, leg.Strike, leg.ExpirationDate)
+		h := p.held[c]
+		h.quantity -= leg.Quantity
+		if h.quantity <= 0 {
+			delete(p.held, c)
+		} else {
+			p.held[c] = h
+		}
+	}
+}
+
+type planner struct {
+	broker    string
+	lookup    Lookup
+	opts      Options
+	sectors   map[string]string
+	positions []*position
+	preview   *models.ImportPreview
+}
+
+// plan splits an order into its closing and opening parts; a roll submitted
+// as one order produces both a close and a new trade
+func (pl *planner) plan(o *order) error {
+	var closing, opening []Execution
+	for _, e := range o.execs {
+		if e.Opening {
+			opening = append(opening, e)
+		} else {
+			closing = append(closing, e)
+		}
+	}
+
+	if len(closing) > 0 {
+		if err := pl.planClose(o, closing); err != nil {
+			return err
+		}
+	}
+	if len(opening) > 0 {
+		if err := pl.planTrade(o, opening); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planTrade turns the opening part of an order into a trade request
+func (pl *planner) planTrade(o *order, execs []Execution) error {
+	legs := mergeLegs(execs)
+	externalID := pl.externalID(o.id)
+
+	var expiration time.Time
+	for _, leg := range legs {
+		if leg.OptionType != models.OptionTypeStock && leg.ExpirationDate.After(expiration) {
+			expiration = leg.ExpirationDate
+		}
+	}
+	if expiration.IsZero() {
+		pl.preview.Skipped = append(pl.preview.Skipped,
+			fmt.Sprintf("order %s: stock-only %s order is not an options trade", o.id, o.ticker))
+		return nil
+	}
+
+	sector, err := pl.sectorFor(o.ticker)
+	if err != nil {
+		return err
+	}
+
+	trade := models.ImportedTrade{
+		ExternalID: externalID,
+		Warnings:   []string{},
+	}
+
+	strategy := InferStrategy(legs)
+	if strategy == "" {
+		strategy = UnclassifiedStrategy
+		trade.Warnings = append(trade.Warnings, "leg structure matches no known strategy type")
+	}
+
+	req := models.TradeRequest{
+		Ticker:         o.ticker,
+		Sector:         sector,
+		StrategyType:   strategy,
+		EntryDate:      dateOf(o.executedAt),
+		ExpirationDate: expiration,
+		Notes:          fmt.Sprintf("Imported from %s order %s", pl.broker, o.id),
+		Legs:           make([]models.LegRequest, 0, len(legs)),
+		ExternalID:     externalID,
+	}
+	for _, leg := range legs {
+		lr := models.LegRequest{
+			OptionType:     leg.OptionType,
+			Side:           leg.Side,
+			Strike:         leg.Strike,
+			ExpirationDate: leg.ExpirationDate,
+			Quantity:       leg.Quantity,
+			Premium:        leg.Premium,
+		}
+		if leg.OptionType == models.OptionTypeStock {
+			lr.ExpirationDate = time.Time{}
+		}
+		req.Legs = append(req.Legs, lr)
+	}
+	if err := models.ValidateTradeRequest(req); err != nil {
+		trade.Warnings = append(trade.Warnings, err.Error())
+	}
+	trade.Request = req
+	trade.OpenFill = netFill(legs, execs, models.FillActionOpen, o.executedAt, externalID+":open")
+
+	trade.Duplicate, err = pl.lookup.TradeExternalIDExists(externalID)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate trade: %w", err)
+	}
+	if trade.Duplicate {
+		pl.preview.Duplicates++
+	} else {
+		pl.positions = append(pl.positions, newPosition(o.ticker, legs, 0, externalID))
+	}
+
+	pl.preview.Trades = append(pl.preview.Trades, trade)
+	return nil
+}
+
+// planClose matches the closing part of an order to an open position
+func (pl *planner) planClose(o *order, execs []Execution) error {
+	legs := mergeLegs(execs)
+	externalID := pl.externalID(o.id) + ":close"
+
+	c := models.ImportedClose{
+		ExternalID: externalID,
+		Ticker:     o.ticker,
+		Fill:       netFill(legs, execs, models.FillActionClose, o.executedAt, externalID),
+		Warnings:   []string{},
+	}
+
+	var err error
+	c.Duplicate, err = pl.lookup.FillExternalIDExists(externalID)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate fill: %w", err)
+	}
+	if c.Duplicate {
+		pl.preview.Duplicates++
+		pl.preview.Closes = append(pl.preview.Closes, c)
+		return nil
+	}
+
+	// Prefer a position the order closes outright over one it only adjusts
+	var match *position
+	for _, p := range pl.positions {
+		if p.closedBy(o.ticker, legs) && (match == nil || p.closesWhole(legs) && !match.closesWhole(legs)) {
+			match = p
+		}
+	}
+	if match == nil {
+		c.Warnings = append(c.Warnings, fmt.Sprintf("no open %s trade holds the contracts closed by order %s", o.ticker, o.id))
+	} else {
+		c.TradeID = match.tradeID
+		c.TradeExternalID = match.externalID
+		if !match.closesWhole(legs) {
+			c.Adjustment = true
+			c.Warnings = append(c.Warnings, fmt.Sprintf("order %s closes only part of the trade's legs; it is recorded as an adjustment", o.id))
+		}
+		match.consume(legs)
+	}
+
+	pl.preview.Closes = append(pl.preview.Closes, c)
+	return nil
+}
+
+// externalID namespaces a broker order ID so IDs from different brokers
+// cannot collide
+func (pl *planner) externalID(orderID string) string {
+	return pl.broker + ":" + orderID
+}
+
+// sectorFor reuses the sector of the ticker's most recent trade
+func (pl *planner) sectorFor(ticker string) (string, error) {
+	if sector, ok := pl.sectors[ticker]; ok {
+		return sector, nil
+	}
+	sector, err := pl.lookup.LatestSectorForTicker(ticker)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up sector for %s: %w", ticker, err)
+	}
+	if sector == "" {
+		sector = pl.opts.DefaultSector
+	}
+	if sector == "" {
+		sector = DefaultSector
+	}
+	pl.sectors[ticker] = sector
+	return sector, nil
+}
+
+// mergeLegs combines partial executions of the same contract and side into a
+// single leg priced at the quantity-weighted average
+func mergeLegs(execs []Execution) []models.Leg {
+	type key struct {
+		contract contract
+		side     string
+	}
+	index := make(map[key]int)
+	var legs []models.Leg
+	for _, e := range execs {
+		k := key{contractOf(e.OptionType, e.Strike, e.Expiration), e.Side}
+		if i, ok := index[k]; ok {
+			leg := &legs[i]
+			total := leg.Premium*float64(leg.Quantity) + e.Price*float64(e.Quantity)
+			leg.Quantity += e.Quantity
+			leg.Premium = round(total / float64(leg.Quantity))
+			continue
+		}
+		index[k] = len(legs)
+		legs = append(legs, models.Leg{
+			OptionType:     e.OptionType,
+			Side:           e.Side,
+			Strike:         e.Strike,
+			ExpirationDate: e.Expiration,
+			Quantity:       e.Quantity,
+			Premium:        e.Price,
+		})
+	}
+	return legs
+}
+
+// netFill prices an order as a position-level fill: the quantity is the
+// number of whole units (the GCD of the leg quantities) and the price is the
+// net per-share amount per unit
+func netFill(legs []models.Leg, execs []Execution, action string, at time.Time, externalID string) models.FillRequest {
+	units := 0
+	cash := 0.0
+	for _, leg := range legs {
+		units = gcd(units, leg.Quantity)
+		amount := leg.Premium * float64(leg.Quantity) * leg.Multiplier()
+		if leg.Side == models.SideBuy {
+			amount = -amount
+		}
+		cash += amount
+	}
+	if units == 0 {
+		units = 1
+	}
+
+	fees := 0.0
+	for _, e := range execs {
+		fees += e.Fees
+	}
+
+	side := models.SideSell
+	if cash < 0 {
+		side = models.SideBuy
+	}
+	return models.FillRequest{
+		Action:     action,
+		Side:       side,
+		Price:      round(math.Abs(cash) / float64(units) / models.ContractMultiplier),
+		Quantity:   units,
+		Fees:       round(fees),
+		FilledAt:   at,
+		ExternalID: externalID,
+	}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// round rounds to four decimal places to absorb float noise in net prices
+func round(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// dateOf returns the calendar date of t as midnight UTC, matching how
+// entry dates are stored elsewhere
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
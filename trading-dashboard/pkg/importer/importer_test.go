@@ -0,0 +1,264 @@
+package importer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// fakeLookup stands in for the trade service with an empty database that
+// remembers what was imported
+type fakeLookup struct {
+	trades map[string]bool
+	fills  map[string]bool
+}
+
+func newFakeLookup() *fakeLookup {
+	return &fakeLookup{trades: make(map[string]bool), fills: make(map[string]bool)}
+}
+
+func (l *fakeLookup) TradeExternalIDExists(externalID string) (bool, error) {
+	return l.trades[externalID], nil
+}
+
+func (l *fakeLookup) FillExternalIDExists(externalID string) (bool, error) {
+	return l.fills[externalID], nil
+}
+
+func (l *fakeLookup) GetOpenTrades() ([]models.OptionsTrade, error) {
+	return []models.OptionsTrade{}, nil
+}
+
+func (l *fakeLookup) LatestSectorForTicker(ticker string) (string, error) {
+	return "", nil
+}
+
+// apply records a preview the way ApplyImport would write it
+func (l *fakeLookup) apply(preview *models.ImportPreview) {
+	for _, t := range preview.Trades {
+		l.trades[t.ExternalID] = true
+		l.fills[t.OpenFill.ExternalID] = true
+	}
+	for _, c := range preview.Closes {
+		l.fills[c.ExternalID] = true
+	}
+}
+
+type wantTrade struct {
+	ticker   string
+	strategy string
+	legs     int
+}
+
+type wantClose struct {
+	ticker     string
+	trade      string // external ID of the trade the close is matched to
+	adjustment bool
+}
+
+func TestPreview(t *testing.T) {
+	tests := []struct {
+		broker string
+		file   string
+		trades []wantTrade
+		closes []wantClose
+	}{
+		{
+			broker: BrokerThinkorswim,
+			file:   "thinkorswim.csv",
+			trades: []wantTrade{
+				{"SPY", "Bull Put Spread", 2},
+				{"QQQ", "Iron Condor", 4},
+				{"AAPL", "Long Call", 1},
+			},
+			closes: []wantClose{
+				{"SPY", "thinkorswim:20250114094512-SPY", false},
+			},
+		},
+		{
+			broker: BrokerIBKR,
+			file:   "ibkr_flex.xml",
+			trades: []wantTrade{
+				{"XLE", "Bear Call Spread", 2},
+				{"KO", "Covered Call", 2},
+			},
+			closes: []wantClose{
+				{"XLE", "ibkr:551000001", false},
+			},
+		},
+		{
+			broker: BrokerTastytrade,
+			file:   "tastytrade.csv",
+			trades: []wantTrade{
+				{"NVDA", "Bull Call Spread", 2},
+				{"IWM", "Iron Condor", 4},
+			},
+			// Only the short legs of the condor are bought back
+			closes: []wantClose{
+				{"IWM", "tastytrade:310000001", true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.broker, func(t *testing.T) {
+			parser, err := ParserFor(tt.broker)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lookup := newFakeLookup()
+
+			preview := previewFile(t, parser, tt.file, lookup)
+			if len(preview.Trades) != len(tt.trades) {
+				t.Fatalf("got %d trades, want %d", len(preview.Trades), len(tt.trades))
+			}
+			for i, want := range tt.trades {
+				got := preview.Trades[i]
+				if got.Request.Ticker != want.ticker || got.Request.StrategyType != want.strategy || len(got.Request.Legs) != want.legs {
+					t.Errorf("trade %d: got %s %s with %d legs, want %s %s with %d legs", i,
+						got.Request.Ticker, got.Request.StrategyType, len(got.Request.Legs),
+						want.ticker, want.strategy, want.legs)
+				}
+				if got.Duplicate {
+					t.Errorf("trade %d: unexpectedly flagged duplicate", i)
+				}
+			}
+
+			if len(preview.Closes) != len(tt.closes) {
+				t.Fatalf("got %d closes, want %d", len(preview.Closes), len(tt.closes))
+			}
+			for i, want := range tt.closes {
+				got := preview.Closes[i]
+				if got.Ticker != want.ticker || got.TradeExternalID != want.trade {
+					t.Errorf("close %d: got %s matched to %q, want %s matched to %q", i,
+						got.Ticker, got.TradeExternalID, want.ticker, want.trade)
+				}
+				if got.Adjustment != want.adjustment {
+					t.Errorf("close %d: got adjustment %v, want %v", i, got.Adjustment, want.adjustment)
+				}
+				if !want.adjustment && len(got.Warnings) > 0 {
+					t.Errorf("close %d: unexpected warnings %v", i, got.Warnings)
+				}
+			}
+			if preview.Duplicates != 0 {
+				t.Errorf("got %d duplicates on first import, want 0", preview.Duplicates)
+			}
+
+			// Importing the same statement again must flag every row
+			lookup.apply(preview)
+			again := previewFile(t, parser, tt.file, lookup)
+			if want := len(tt.trades) + len(tt.closes); again.Duplicates != want {
+				t.Errorf("got %d duplicates on re-import, want %d", again.Duplicates, want)
+			}
+			for i, trade := range again.Trades {
+				if !trade.Duplicate {
+					t.Errorf("re-imported trade %d not flagged duplicate", i)
+				}
+			}
+			for i, c := range again.Closes {
+				if !c.Duplicate {
+					t.Errorf("re-imported close %d not flagged duplicate", i)
+				}
+			}
+		})
+	}
+}
+
+// tastytradeHeader is the header row of a Tastytrade transaction history
+const tastytradeHeader = "Date,Type,Sub Type,Action,Symbol,Instrument Type,Description,Value,Quantity,Average Price,Commissions,Fees,Multiplier,Root Symbol,Underlying Symbol,Expiration Date,Strike Price,Call or Put,Order #,Currency\n"
+
+// tastytradeRow is one IWM option execution in a Tastytrade transaction
+// history
+func tastytradeRow(date, action, symbol string, strike int, callPut, order string) string {
+	return date + ",Trade,," + action + "," + symbol + ",Equity Option,,0.00,1,0.00,0.00,0.00,100,IWM,IWM,2/21/25," +
+		strconv.Itoa(strike) + "," + callPut + "," + order + ",USD\n"
+}
+
+func TestPreviewMatchesEachCloseOnce(t *testing.T) {
+	// Two identical put spreads opened and closed in separate orders
+	statement := tastytradeHeader +
+		tastytradeRow("2025-01-14T10:00:00-0500", "SELL_TO_OPEN", "IWM   250221P00215000", 215, "PUT", "1") +
+		tastytradeRow("2025-01-14T10:00:00-0500", "BUY_TO_OPEN", "IWM   250221P00205000", 205, "PUT", "1") +
+		tastytradeRow("2025-01-15T10:00:00-0500", "SELL_TO_OPEN", "IWM   250221P00215000", 215, "PUT", "2") +
+		tastytradeRow("2025-01-15T10:00:00-0500", "BUY_TO_OPEN", "IWM   250221P00205000", 205, "PUT", "2") +
+		tastytradeRow("2025-01-20T10:00:00-0500", "BUY_TO_CLOSE", "IWM   250221P00215000", 215, "PUT", "3") +
+		tastytradeRow("2025-01-20T10:00:00-0500", "SELL_TO_CLOSE", "IWM   250221P00205000", 205, "PUT", "3") +
+		tastytradeRow("2025-01-21T10:00:00-0500", "BUY_TO_CLOSE", "IWM   250221P00215000", 215, "PUT", "4") +
+		tastytradeRow("2025-01-21T10:00:00-0500", "SELL_TO_CLOSE", "IWM   250221P00205000", 205, "PUT", "4")
+
+	preview := previewStatement(t, BrokerTastytrade, strings.NewReader(statement))
+	if len(preview.Closes) != 2 {
+		t.Fatalf("got %d closes, want 2", len(preview.Closes))
+	}
+	for i, want := range []string{"tastytrade:1", "tastytrade:2"} {
+		if got := preview.Closes[i].TradeExternalID; got != want {
+			t.Errorf("close %d matched to %q, want %q", i, got, want)
+		}
+	}
+}
+
+func previewFile(t *testing.T, parser Parser, name string, lookup Lookup) *models.ImportPreview {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	preview, err := Preview(parser, f, lookup, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return preview
+}
+
+// previewStatement previews a statement against an empty database
+func previewStatement(t *testing.T, broker string, r io.Reader) *models.ImportPreview {
+	t.Helper()
+	parser, err := ParserFor(broker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	preview, err := Preview(parser, r, newFakeLookup(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return preview
+}
+
+func TestPositionClosesWhole(t *testing.T) {
+	exp := time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC)
+	leg := func(side string, strike float64, qty int) models.Leg {
+		return models.Leg{OptionType: models.OptionTypePut, Side: side, Strike: strike, ExpirationDate: exp, Quantity: qty}
+	}
+	// A 2-lot put spread
+	open := []models.Leg{leg(models.SideSell, 215, 2), leg(models.SideBuy, 205, 2)}
+
+	tests := []struct {
+		name  string
+		close []models.Leg
+		whole bool
+	}{
+		{"all units", []models.Leg{leg(models.SideBuy, 215, 2), leg(models.SideSell, 205, 2)}, true},
+		{"one unit", []models.Leg{leg(models.SideBuy, 215, 1), leg(models.SideSell, 205, 1)}, true},
+		{"one leg", []models.Leg{leg(models.SideBuy, 215, 2)}, false},
+		{"uneven", []models.Leg{leg(models.SideBuy, 215, 2), leg(models.SideSell, 205, 1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPosition("IWM", open, 0, "x")
+			if !p.closedBy("IWM", tt.close) {
+				t.Fatal("close not matched to the position")
+			}
+			if got := p.closesWhole(tt.close); got != tt.whole {
+				t.Errorf("closesWhole = %v, want %v", got, tt.whole)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// header maps CSV column names to their index
+type header map[string]int
+
+func newHeader(record []string) header {
+	h := make(header, len(record))
+	for i, name := range record {
+		h[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	return h
+}
+
+// require reports the first of the named columns missing from the header
+func (h header) require(names ...string) error {
+	for _, name := range names {
+		if _, ok := h[strings.ToLower(name)]; !ok {
+			return fmt.Errorf("missing column %q", name)
+		}
+	}
+	return nil
+}
+
+// get returns the trimmed value of a named column, or "" if absent
+func (h header) get(record []string, name string) string {
+	i, ok := h[strings.ToLower(name)]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// parseNumber parses broker-formatted numbers such as "1,210.00", "$2.10",
+// "+1" and "(0.65)"
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	s = strings.NewReplacer(",", "", "$", "", "+", "").Replace(s)
+	if s == "" || s == "--" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// parseQuantity parses a contract or share count, dropping its sign
+func parseQuantity(s string) (int, error) {
+	v, err := parseNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	q := int(math.Round(math.Abs(v)))
+	if q == 0 {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return q, nil
+}
+
+// parseOptionType maps broker put/call and asset labels to leg option types
+func parseOptionType(s string) (string, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "C", "CALL":
+		return models.OptionTypeCall, true
+	case "P", "PUT":
+		return models.OptionTypePut, true
+	case "STOCK", "ETF", "STK", "EQUITY":
+		return models.OptionTypeStock, true
+	}
+	return "", false
+}
+
+// parseDate parses a date in the first matching layout, returning midnight UTC
+func parseDate(s string, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return dateOf(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// parseMarketTime parses a timestamp without zone information as exchange
+// local time
+func parseMarketTime(s string, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, models.MarketLocation()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
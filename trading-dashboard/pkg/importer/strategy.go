@@ -0,0 +1,200 @@
+package importer
+
+import (
+	"sort"
+
+	"trading-dashboard/pkg/models"
+)
+
+// InferStrategy names the seeded strategy type whose leg structure matches
+// the given legs, or returns "" when none does
+func InferStrategy(legs []models.Leg) string {
+	var options, stock []models.Leg
+	for _, leg := range legs {
+		if leg.OptionType == models.OptionTypeStock {
+			stock = append(stock, leg)
+		} else {
+			options = append(options, leg)
+		}
+	}
+
+	if len(stock) > 0 {
+		if len(stock) == 1 && stock[0].Side == models.SideBuy && len(options) == 1 &&
+			options[0].OptionType == models.OptionTypeCall && options[0].Side == models.SideSell {
+			return "Covered Call"
+		}
+		return ""
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		if !options[i].ExpirationDate.Equal(options[j].ExpirationDate) {
+			return options[i].ExpirationDate.Before(options[j].ExpirationDate)
+		}
+		return options[i].Strike < options[j].Strike
+	})
+
+	switch len(options) {
+	case 1:
+		return inferSingle(options[0])
+	case 2:
+		return inferTwoLeg(options[0], options[1])
+	case 3:
+		return inferButterfly(options)
+	case 4:
+		return inferIron(options)
+	}
+	return ""
+}
+
+func inferSingle(leg models.Leg) string {
+	switch {
+	case leg.OptionType == models.OptionTypeCall && leg.Side == models.SideBuy:
+		return "Long Call"
+	case leg.OptionType == models.OptionTypePut && leg.Side == models.SideBuy:
+		return "Long Put"
+	case leg.OptionType == models.OptionTypePut && leg.Side == models.SideSell:
+		return "Cash-Secured Put"
+	}
+	return ""
+}
+
+// inferTwoLeg recognizes verticals, ratio backspreads, calendars, diagonals,
+// straddles and strangles. The legs are sorted by expiration, then strike.
+func inferTwoLeg(a, b models.Leg) string {
+	if a.OptionType != b.OptionType {
+		if a.Side == models.SideBuy && b.Side == models.SideBuy &&
+			a.Quantity == b.Quantity && a.ExpirationDate.Equal(b.ExpirationDate) {
+			if a.Strike == b.Strike {
+				return "Straddle"
+			}
+			return "Strangle"
+		}
+		return ""
+	}
+	if a.Side == b.Side {
+		return ""
+	}
+	calls := a.OptionType == models.OptionTypeCall
+
+	if !a.ExpirationDate.Equal(b.ExpirationDate) {
+		// Calendar and diagonal spreads sell the near leg and buy the far leg
+		if a.Side != models.SideSell || a.Quantity != b.Quantity {
+			return ""
+		}
+		switch {
+		case a.Strike == b.Strike && calls:
+			return "Calendar Call Spread"
+		case a.Strike == b.Strike:
+			return "Calendar Put Spread"
+		case calls:
+			return "Diagonal Call Spread"
+		default:
+			return "Diagonal Put Spread"
+		}
+	}
+
+	if a.Strike == b.Strike {
+		return ""
+	}
+	low, high := a, b
+
+	if low.Quantity != high.Quantity {
+		// Backspreads sell fewer contracts nearer the money and buy more
+		// further out
+		short, long := low, high
+		if short.Side != models.SideSell {
+			short, long = high, low
+		}
+		if short.Quantity >= long.Quantity {
+			return ""
+		}
+		if calls && short.Strike < long.Strike {
+			return "Call Ratio Backspread"
+		}
+		if !calls && short.Strike > long.Strike {
+			return "Put Ratio Backspread"
+		}
+		return ""
+	}
+
+	switch {
+	case calls && low.Side == models.SideBuy:
+		return "Bull Call Spread"
+	case calls:
+		return "Bear Call Spread"
+	case high.Side == models.SideSell:
+		return "Bull Put Spread"
+	default:
+		return "Bear Put Spread"
+	}
+}
+
+// inferButterfly recognizes butterflies and broken wings: three strikes of
+// one type and expiration with a double-sized body opposite the wings
+func inferButterfly(legs []models.Leg) string {
+	low, body, high := legs[0], legs[1], legs[2]
+	if low.OptionType != body.OptionType || body.OptionType != high.OptionType {
+		return ""
+	}
+	if !low.ExpirationDate.Equal(high.ExpirationDate) || !low.ExpirationDate.Equal(body.ExpirationDate) {
+		return ""
+	}
+	if low.Side != high.Side || low.Side == body.Side {
+		return ""
+	}
+	if low.Quantity != high.Quantity || body.Quantity != 2*low.Quantity {
+		return ""
+	}
+
+	calls := low.OptionType == models.OptionTypeCall
+	even := body.Strike-low.Strike == high.Strike-body.Strike
+
+	if body.Side == models.SideSell {
+		switch {
+		case even && calls:
+			return "Long Call Butterfly"
+		case even:
+			return "Long Put Butterfly"
+		case calls:
+			return "Call Broken Wing"
+		default:
+			return "Put Broken Wing"
+		}
+	}
+	if calls {
+		return "Inverse Call Broken Wing"
+	}
+	return "Inverse Put Broken Wing"
+}
+
+// inferIron recognizes iron condors and iron butterflies: a short put spread
+// and a short call spread sharing an expiration
+func inferIron(legs []models.Leg) string {
+	var puts, calls []models.Leg
+	for _, leg := range legs {
+		if !leg.ExpirationDate.Equal(legs[0].ExpirationDate) || leg.Quantity != legs[0].Quantity {
+			return ""
+		}
+		if leg.OptionType == models.OptionTypePut {
+			puts = append(puts, leg)
+		} else {
+			calls = append(calls, leg)
+		}
+	}
+	if len(puts) != 2 || len(calls) != 2 {
+		return ""
+	}
+
+	// Sorted by strike: long put, short put, short call, long call
+	if puts[0].Side != models.SideBuy || puts[1].Side != models.SideSell ||
+		calls[0].Side != models.SideSell || calls[1].Side != models.SideBuy {
+		return ""
+	}
+	if puts[1].Strike == calls[0].Strike {
+		return "Iron Butterfly"
+	}
+	if puts[1].Strike < calls[0].Strike {
+		return "Iron Condor"
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// tastytradeParser reads a Tastytrade transaction history CSV export.
+// Only rows of type "Trade" are imported; expirations, assignments and cash
+// movements are reported as skipped.
+type tastytradeParser struct{}
+
+func (tastytradeParser) Broker() string { return BrokerTastytrade }
+
+func (tastytradeParser) Parse(r io.Reader) ([]Execution, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	record, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	h := newHeader(record)
+	if err := h.require("Date", "Type", "Action", "Instrument Type", "Value", "Quantity", "Order #"); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		execs   []Execution
+		skipped []string
+		line    = 1
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line++
+		if isBlank(record) {
+			continue
+		}
+
+		if kind := h.get(record, "Type"); !strings.EqualFold(kind, "Trade") {
+			skipped = append(skipped, fmt.Sprintf("line %d: %s %s", line, kind, h.get(record, "Description")))
+			continue
+		}
+
+		var e Execution
+		switch instrument := h.get(record, "Instrument Type"); instrument {
+		case "Equity Option":
+			optionType, ok := parseOptionType(h.get(record, "Call or Put"))
+			if !ok || optionType == models.OptionTypeStock {
+				return nil, nil, fmt.Errorf("line %d: invalid call or put %q", line, h.get(record, "Call or Put"))
+			}
+			e.OptionType = optionType
+			if e.Strike, err = parseNumber(h.get(record, "Strike Price")); err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if e.Expiration, err = parseDate(h.get(record, "Expiration Date"), "1/2/06", "1/2/2006", "2006-01-02"); err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		case "Equity":
+			e.OptionType = models.OptionTypeStock
+		default:
+			skipped = append(skipped, fmt.Sprintf("line %d: unsupported instrument %q", line, instrument))
+			continue
+		}
+
+		e.Ticker = h.get(record, "Underlying Symbol")
+		if e.Ticker == "" {
+			e.Ticker = h.get(record, "Root Symbol")
+		}
+		if e.Ticker == "" {
+			e.Ticker = h.get(record, "Symbol")
+		}
+		e.Ticker = strings.ToUpper(e.Ticker)
+
+		// Actions look like SELL_TO_OPEN or BUY_TO_CLOSE
+		action := strings.ToUpper(h.get(record, "Action"))
+		switch {
+		case strings.HasPrefix(action, "BUY"):
+			e.Side = models.SideBuy
+		case strings.HasPrefix(action, "SELL"):
+			e.Side = models.SideSell
+		default:
+			return nil, nil, fmt.Errorf("line %d: invalid action %q", line, action)
+		}
+		switch {
+		case strings.HasSuffix(action, "OPEN"):
+			e.Opening = true
+		case strings.HasSuffix(action, "CLOSE"):
+		default:
+			skipped = append(skipped, fmt.Sprintf("line %d: action %q has no position effect", line, action))
+			continue
+		}
+
+		if e.Quantity, err = parseQuantity(h.get(record, "Quantity")); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		// Value is the signed dollar amount of the execution; dividing by the
+		// share count gives a per-share price independent of rounding in the
+		// Average Price column
+		value, err := parseNumber(h.get(record, "Value"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		multiplier := 1.0
+		if e.OptionType != models.OptionTypeStock {
+			multiplier = models.ContractMultiplier
+			if m := h.get(record, "Multiplier"); m != "" {
+				if multiplier, err = parseNumber(m); err != nil || multiplier <= 0 {
+					return nil, nil, fmt.Errorf("line %d: invalid multiplier %q", line, m)
+				}
+			}
+		}
+		e.Price = round(math.Abs(value) / (float64(e.Quantity) * multiplier))
+
+		commissions, err := parseNumber(h.get(record, "Commissions"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		fees, err := parseNumber(h.get(record, "Fees"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		e.Fees = math.Abs(commissions) + math.Abs(fees)
+
+		if e.ExecutedAt, err = parseTimestamp(h.get(record, "Date")); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		e.OrderID = h.get(record, "Order #")
+		if e.OrderID == "" {
+			return nil, nil, fmt.Errorf("line %d: missing order number", line)
+		}
+
+		execs = append(execs, e)
+	}
+
+	return execs, skipped, nil
+}
+
+// parseTimestamp parses Tastytrade's ISO-8601 timestamps, which carry their
+// own UTC offset
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05-0700", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.In(models.MarketLocation()), nil
+		}
+	}
+	return parseMarketTime(s, "2006-01-02 15:04:05", "1/2/2006 15:04")
+}
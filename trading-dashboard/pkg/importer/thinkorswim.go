@@ -0,0 +1,139 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+)
+
+// thinkorswimParser reads the "Account Trade History" section of a
+// thinkorswim Account Statement CSV export. Multi-leg orders list the order
+// time on the first leg only; following rows with an empty Exec Time belong
+// to the same order. The statement carries no order numbers, so orders are
+// identified by execution time and symbol. Commissions are reported in a
+// separate section and are not imported.
+type thinkorswimParser struct{}
+
+func (thinkorswimParser) Broker() string { return BrokerThinkorswim }
+
+func (thinkorswimParser) Parse(r io.Reader) ([]Execution, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var (
+		execs   []Execution
+		skipped []string
+		h       header
+		inTrade bool
+		current *Execution
+		seen    = make(map[string]int)
+		line    int
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line++
+
+		if isBlank(record) {
+			continue
+		}
+		if !inTrade {
+			inTrade = strings.EqualFold(strings.TrimSpace(record[0]), "Account Trade History")
+			continue
+		}
+		// Trade rows start with an empty column; anything else is the title
+		// of the next section
+		if strings.TrimSpace(record[0]) != "" {
+			break
+		}
+		if h == nil {
+			h = newHeader(record)
+			if err := h.require("Exec Time", "Side", "Qty", "Pos Effect", "Symbol", "Exp", "Strike", "Type", "Price"); err != nil {
+				return nil, nil, fmt.Errorf("account trade history: %w", err)
+			}
+			continue
+		}
+
+		optionType, ok := parseOptionType(h.get(record, "Type"))
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("line %d: unsupported instrument %q", line, h.get(record, "Type")))
+			continue
+		}
+
+		e := Execution{
+			Ticker:     strings.ToUpper(h.get(record, "Symbol")),
+			OptionType: optionType,
+			Side:       strings.ToLower(h.get(record, "Side")),
+			Opening:    strings.Contains(strings.ToUpper(h.get(record, "Pos Effect")), "OPEN"),
+		}
+		if e.Side != models.SideBuy && e.Side != models.SideSell {
+			return nil, nil, fmt.Errorf("line %d: invalid side %q", line, h.get(record, "Side"))
+		}
+
+		if execTime := h.get(record, "Exec Time"); execTime != "" {
+			at, err := parseMarketTime(execTime, "1/2/06 15:04:05", "1/2/2006 15:04:05")
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			e.ExecutedAt = at
+			e.OrderID = at.Format("20060102150405") + "-" + e.Ticker
+			seen[e.OrderID]++
+			if n := seen[e.OrderID]; n > 1 {
+				e.OrderID = fmt.Sprintf("%s-%d", e.OrderID, n)
+			}
+		} else if current != nil {
+			e.ExecutedAt = current.ExecutedAt
+			e.OrderID = current.OrderID
+		} else {
+			return nil, nil, fmt.Errorf("line %d: leg without an order time", line)
+		}
+
+		if e.Quantity, err = parseQuantity(h.get(record, "Qty")); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if e.Price, err = parseNumber(h.get(record, "Price")); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if optionType != models.OptionTypeStock {
+			if e.Strike, err = parseNumber(h.get(record, "Strike")); err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			// Weekly series carry a suffix such as "17 JAN 25 (Wkly)"
+			exp := strings.Fields(h.get(record, "Exp"))
+			if len(exp) < 3 {
+				return nil, nil, fmt.Errorf("line %d: invalid expiration %q", line, h.get(record, "Exp"))
+			}
+			if e.Expiration, err = parseDate(strings.Join(exp[:3], " "), "2 Jan 06", "2 Jan 2006"); err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		execs = append(execs, e)
+		current = &e
+	}
+
+	if h == nil {
+		return nil, nil, fmt.Errorf("no Account Trade History section found")
+	}
+	return execs, skipped, nil
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
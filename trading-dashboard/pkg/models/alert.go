@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Alert rules reported on alerts
+const (
+	AlertTargetHit  = "target_hit"
+	AlertStopHit    = "stop_hit"
+	AlertExpiring   = "expiring"
+	AlertRatingSign = "rating_sign"
+)
+
+// SettingAlertRules is the settings key holding the alert rules as JSON
+const SettingAlertRules = "alert_rules"
+
+// AlertToggle is an alert rule with no parameters
+type AlertToggle struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ExpiringAlertRule alerts when an open trade is Days or fewer calendar days
+// from expiration
+type ExpiringAlertRule struct {
+	Enabled bool `json:"enabled"`
+	Days    int  `json:"days"`
+}
+
+// AlertRules selects the conditions checked by the alert scheduler.
+// TargetStop compares the latest underlying price with each open trade's
+// target price and stop loss; RatingSign fires when a sector's rating moves
+// between positive, zero and negative from one snapshot to the next.
+type AlertRules struct {
+	TargetStop AlertToggle       `json:"target_stop"`
+	Expiring   ExpiringAlertRule `json:"expiring"`
+	RatingSign AlertToggle       `json:"rating_sign"`
+}
+
+// DefaultAlertRules returns the rules used until others are saved
+func DefaultAlertRules() AlertRules {
+	return AlertRules{
+		TargetStop: AlertToggle{Enabled: true},
+		Expiring:   ExpiringAlertRule{Enabled: true, Days: 7},
+		RatingSign: AlertToggle{Enabled: true},
+	}
+}
+
+// Validate checks the alert rule parameters
+func (r AlertRules) Validate() error {
+	if r.Expiring.Days < 0 || r.Expiring.Days > 365 {
+		return fmt.Errorf("%s: days must be between 0 and 365", AlertExpiring)
+	}
+	return nil
+}
+
+// Alert is an entry in the alert inbox. TradeID is set for trade alerts and
+// Sector for rating alerts.
+type Alert struct {
+	ID        int64      `json:"id"`
+	Rule      string     `json:"rule"`
+	TradeID   *int64     `json:"trade_id,omitempty"`
+	Ticker    string     `json:"ticker"`
+	Sector    string     `json:"sector"`
+	Message   string     `json:"message"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+}
+
+// UnderlyingPrice is the latest known price of an underlying
+type UnderlyingPrice struct {
+	Ticker    string    `json:"ticker"`
+	Price     float64   `json:"price"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
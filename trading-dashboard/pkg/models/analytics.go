@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Trade outcomes used by the analytics reports
+const (
+	OutcomeWin  = "win"
+	OutcomeLoss = "loss"
+	OutcomeFlat = "flat"
+)
+
+// Sector rating buckets used to group trades by the conviction at entry
+const (
+	RatingBucketBearish = "Bearish (-3 to -1)"
+	RatingBucketNeutral = "Neutral (-1 to +1)"
+	RatingBucketBullish = "Bullish (+1 to +3)"
+	RatingBucketUnrated = "Unrated"
+)
+
+// RatedTrade is a finished trade joined to the market rating snapshot in
+// effect when it was opened
+type RatedTrade struct {
+	TradeID        int64     `json:"trade_id"`
+	Ticker         string    `json:"ticker"`
+	Sector         string    `json:"sector"`
+	StrategyType   string    `json:"strategy_type"`
+	Category       string    `json:"category"`
+	EntryDate      time.Time `json:"entry_date"`
+	MarketRatingID *int64    `json:"market_rating_id,omitempty"`
+	OverallRating  *float64  `json:"overall_rating,omitempty"`
+	SectorRating   *float64  `json:"sector_rating,omitempty"`
+	RatingBucket   string    `json:"rating_bucket"`
+	RealizedPnL    float64   `json:"realized_pnl"`
+	Outcome        string    `json:"outcome"`
+}
+
+// OutcomeBucket aggregates the results of a group of trades
+type OutcomeBucket struct {
+	Label       string  `json:"label"`
+	Trades      int     `json:"trades"`
+	Wins        int     `json:"wins"`
+	Losses      int     `json:"losses"`
+	WinRate     float64 `json:"win_rate"`
+	RealizedPnL float64 `json:"realized_pnl"`
+	AveragePnL  float64 `json:"average_pnl"`
+}
+
+// SentimentEdgeReport shows whether the sector ratings at entry line up with
+// trade outcomes
+type SentimentEdgeReport struct {
+	StartDate      time.Time       `json:"start_date"`
+	EndDate        time.Time       `json:"end_date"`
+	Trades         []RatedTrade    `json:"trades"`
+	BySectorRating []OutcomeBucket `json:"by_sector_rating"`
+	ByCategory     []OutcomeBucket `json:"by_category"`
+	Overall        OutcomeBucket   `json:"overall"`
+}
+
+// RatingBucket returns the conviction bucket for a sector rating
+func RatingBucket(rating *float64) string {
+	switch {
+	case rating == nil:
+		return RatingBucketUnrated
+	case *rating <= -1:
+		return RatingBucketBearish
+	case *rating >= 1:
+		return RatingBucketBullish
+	default:
+		return RatingBucketNeutral
+	}
+}
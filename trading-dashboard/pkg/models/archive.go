@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+// ArchiveFormat identifies a Trading Dashboard archive file
+const ArchiveFormat = "trading-dashboard-archive"
+
+// ArchiveVersion is the archive layout written by this application. Archives
+// with a higher version are refused.
+const ArchiveVersion = 1
+
+// Archive import modes. Merge adds the archive to the existing data, skipping
+// records that are already present; replace deletes the existing data first.
+const (
+	ArchiveModeMerge   = "merge"
+	ArchiveModeReplace = "replace"
+)
+
+// Archive is a portable copy of a whole workspace. Checksum is the SHA-256
+// of the compact JSON encoding of Data, so any edit to the data is detected.
+type Archive struct {
+	Format        string      `json:"format"`
+	Version       int         `json:"version"`
+	SchemaVersion int         `json:"schema_version"`
+	ExportedAt    time.Time   `json:"exported_at"`
+	Checksum      string      `json:"checksum"`
+	Data          ArchiveData `json:"data"`
+}
+
+// ArchiveData holds every record in an archive. IDs are those of the
+// exporting database and are remapped when merged into another one.
+type ArchiveData struct {
+	MarketRatings    []MarketRating    `json:"market_ratings"`
+	StrategyTypes    []StrategyType    `json:"strategy_types"`
+	Trades           []ArchivedTrade   `json:"trades"`
+	Settings         map[string]string `json:"settings"`
+	Betas            []Beta            `json:"betas"`
+	UnderlyingPrices []UnderlyingPrice `json:"underlying_prices"`
+	Alerts           []ArchivedAlert   `json:"alerts"`
+}
+
+// ArchivedTrade is a trade with its legs, fills and status history
+type ArchivedTrade struct {
+	OptionsTrade
+	Fills         []Fill         `json:"fills"`
+	StatusHistory []StatusChange `json:"status_history"`
+}
+
+// ArchivedAlert is an inbox alert with the key that stops its condition from
+// alerting again
+type ArchivedAlert struct {
+	Alert
+	DedupeKey string `json:"dedupe_key"`
+}
+
+// ArchiveCounts counts the records of each kind in an archive operation
+type ArchiveCounts struct {
+	MarketRatings    int `json:"market_ratings"`
+	StrategyTypes    int `json:"strategy_types"`
+	Trades           int `json:"trades"`
+	Settings         int `json:"settings"`
+	Betas            int `json:"betas"`
+	UnderlyingPrices int `json:"underlying_prices"`
+	Alerts           int `json:"alerts"`
+}
+
+// ArchiveSummary describes an exported archive
+type ArchiveSummary struct {
+	Path       string        `json:"path"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Checksum   string        `json:"checksum"`
+	Counts     ArchiveCounts `json:"counts"`
+}
+
+// ArchiveImportResult reports what an archive import added and what it
+// skipped as already present
+type ArchiveImportResult struct {
+	Mode       string        `json:"mode"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Imported   ArchiveCounts `json:"imported"`
+	Skipped    ArchiveCounts `json:"skipped"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Reasons a backup was taken, recorded in its file name
+const (
+	BackupReasonStartup    = "startup"
+	BackupReasonScheduled  = "scheduled"
+	BackupReasonManual     = "manual"
+	BackupReasonPreRestore = "pre-restore"
+	BackupReasonPreImport  = "pre-import"
+)
+
+// DefaultBackupRetention is how many backups are kept before the oldest are
+// deleted
+const DefaultBackupRetention = 14
+
+// BackupInfo describes a database snapshot in the backup directory
+type BackupInfo struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	Reason    string    `json:"reason"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// WorkbookSummary describes an exported Excel workbook
+type WorkbookSummary struct {
+	Path    string `json:"path"`
+	Trades  int    `json:"trades"`
+	Legs    int    `json:"legs"`
+	Ratings int    `json:"ratings"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Fill represents an opening or closing transaction on a trade. Fills are
+// recorded at the position level: Price is the net per-share price of the
+// whole structure and Quantity is the number of units (spreads) filled.
+type Fill struct {
+	ID         int64     `json:"id"`
+	TradeID    int64     `json:"trade_id"`
+	Action     string    `json:"action"`
+	Side       string    `json:"side"`
+	Price      float64   `json:"price"`
+	Quantity   int       `json:"quantity"`
+	Fees       float64   `json:"fees"`
+	FilledAt   time.Time `json:"filled_at"`
+	ExternalID string    `json:"external_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// FillRequest represents the data structure for recording a fill.
+// A zero FilledAt defaults to the current time.
+type FillRequest struct {
+	Action   string    `json:"action"`
+	Side     string    `json:"side"`
+	Price    float64   `json:"price"`
+	Quantity int       `json:"quantity"`
+	Fees     float64   `json:"fees"`
+	FilledAt time.Time `json:"filled_at"`
+	// ExternalID is set by the broker importer for de-duplication
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// TradePnL summarizes the profit and loss of a single trade
+type TradePnL struct {
+	TradeID           int64    `json:"trade_id"`
+	Ticker            string   `json:"ticker"`
+	StrategyType      string   `json:"strategy_type"`
+	Status            string   `json:"status"`
+	OpenQuantity      int      `json:"open_quantity"`
+	ClosedQuantity    int      `json:"closed_quantity"`
+	RemainingQuantity int      `json:"remaining_quantity"`
+	CostBasis         float64  `json:"cost_basis"`
+	RealizedPnL       float64  `json:"realized_pnl"`
+	UnrealizedPnL     *float64 `json:"unrealized_pnl,omitempty"`
+	Fees              float64  `json:"fees"`
+}
+
+// PnLSummary aggregates realized P&L across trades for a date range
+type PnLSummary struct {
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	Trades      []TradePnL `json:"trades"`
+	RealizedPnL float64    `json:"realized_pnl"`
+	Fees        float64    `json:"fees"`
+	Winners     int        `json:"winners"`
+	Losers      int        `json:"losers"`
+}
+
+// Fill actions
+const (
+	FillActionOpen  = "open"
+	FillActionClose = "close"
+)
+
+// ValidateFillRequest validates a fill request
+func ValidateFillRequest(req FillRequest) error {
+	if req.Action != FillActionOpen && req.Action != FillActionClose {
+		return fmt.Errorf("invalid fill action: %s", req.Action)
+	}
+	if req.Side != SideBuy && req.Side != SideSell {
+		return fmt.Errorf("invalid side: %s", req.Side)
+	}
+	if req.Price < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+	if req.Quantity <= 0 {
+		return fmt.Errorf("quantity must be greater than zero")
+	}
+	if req.Fees < 0 {
+		return fmt.Errorf("fees cannot be negative")
+	}
+	return nil
+}
+
+// CashFlow returns the net cash effect of the fill including fees;
+// credits are positive and debits negative
+func (f Fill) CashFlow() float64 {
+	amount := f.Price * float64(f.Quantity) * ContractMultiplier
+	if f.Side == SideBuy {
+		amount = -amount
+	}
+	return amount - f.Fees
+}
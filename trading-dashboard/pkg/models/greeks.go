@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+	_ "time/tzdata" // bundle zone data so America/New_York resolves on Windows
+
+	"trading-dashboard/pkg/pricing"
+)
+
+// GreeksRequest holds the market inputs used to value a trade's legs.
+// Volatility and Rate are annualized decimals (0.25 = 25%).
+type GreeksRequest struct {
+	UnderlyingPrice float64 `json:"underlying_price"`
+	Volatility      float64 `json:"volatility"`
+	Rate            float64 `json:"rate"`
+}
+
+// LegGreeks holds the position-weighted Greeks of a single leg
+type LegGreeks struct {
+	LegID  int64          `json:"leg_id"`
+	Greeks pricing.Greeks `json:"greeks"`
+}
+
+// PositionGreeks holds the Greeks of every leg of a trade and their total,
+// scaled to the whole position (contracts x multiplier). Price is in dollars,
+// Delta in shares of the underlying, Gamma in shares of delta per $1 move,
+// Theta in dollars per calendar day, Vega in dollars per volatility point
+// and Rho in dollars per percentage point of rate.
+type PositionGreeks struct {
+	TradeID int64          `json:"trade_id"`
+	AsOf    time.Time      `json:"as_of"`
+	Legs    []LegGreeks    `json:"legs"`
+	Total   pricing.Greeks `json:"total"`
+}
+
+// MarketCloseHour is the hour (America/New_York) at which options stop trading
+const MarketCloseHour = 16
+
+// MarketLocation returns the exchange time zone (America/New_York)
+func MarketLocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ExpirationCutoff returns the exchange close on the calendar day of an
+// expiration date, in America/New_York
+func ExpirationCutoff(expiration time.Time) time.Time {
+	y, m, d := expiration.Date()
+	return time.Date(y, m, d, MarketCloseHour, 0, 0, 0, MarketLocation())
+}
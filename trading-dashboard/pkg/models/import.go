@@ -0,0 +1,45 @@
+package models
+
+// ImportedTrade is a trade reconstructed from a broker opening order
+type ImportedTrade struct {
+	ExternalID string       `json:"external_id"`
+	Request    TradeRequest `json:"request"`
+	OpenFill   FillRequest  `json:"open_fill"`
+	Duplicate  bool         `json:"duplicate"`
+	Warnings   []string     `json:"warnings"`
+}
+
+// ImportedClose is a broker closing order matched to the trade it closes.
+// TradeID refers to an existing trade; TradeExternalID refers to a trade
+// created by the same import. Adjustment is set when the order closes only
+// some of the trade's legs, or not at their ratios; it marks the trade
+// adjusted instead of counting against its quantity.
+type ImportedClose struct {
+	ExternalID      string      `json:"external_id"`
+	Ticker          string      `json:"ticker"`
+	TradeID         int64       `json:"trade_id,omitempty"`
+	TradeExternalID string      `json:"trade_external_id,omitempty"`
+	Fill            FillRequest `json:"fill"`
+	Adjustment      bool        `json:"adjustment"`
+	Duplicate       bool        `json:"duplicate"`
+	Warnings        []string    `json:"warnings"`
+}
+
+// ImportPreview is the dry-run result of parsing a broker statement.
+// Nothing is written until it is applied.
+type ImportPreview struct {
+	Broker     string          `json:"broker"`
+	Trades     []ImportedTrade `json:"trades"`
+	Closes     []ImportedClose `json:"closes"`
+	Skipped    []string        `json:"skipped"`
+	Duplicates int             `json:"duplicates"`
+}
+
+// ImportResult summarizes what applying an import preview wrote
+type ImportResult struct {
+	CreatedTrades     []int64  `json:"created_trades"`
+	ClosedTrades      []int64  `json:"closed_trades"`
+	RecordedFills     int      `json:"recorded_fills"`
+	SkippedDuplicates int      `json:"skipped_duplicates"`
+	Warnings          []string `json:"warnings"`
+}
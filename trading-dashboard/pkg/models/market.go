@@ -22,6 +22,31 @@ type SectorRating struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+// SectorRatingPoint is a sector's rating in a single market rating snapshot
+type SectorRatingPoint struct {
+	MarketRatingID int64     `json:"market_rating_id"`
+	SectorName     string    `json:"sector_name"`
+	Rating         float64   `json:"rating"`
+	RecordedAt     time.Time `json:"recorded_at"`
+}
+
+// SectorRatingChange is the change in one sector between two snapshots.
+// From or To is nil when the sector was not rated in that snapshot.
+type SectorRatingChange struct {
+	SectorName string   `json:"sector_name"`
+	From       *float64 `json:"from"`
+	To         *float64 `json:"to"`
+	Change     float64  `json:"change"`
+}
+
+// RatingComparison describes how the market view moved between two snapshots
+type RatingComparison struct {
+	From          MarketRating         `json:"from"`
+	To            MarketRating         `json:"to"`
+	OverallChange float64              `json:"overall_change"`
+	SectorChanges []SectorRatingChange `json:"sector_changes"`
+}
+
 // MarketRatingRequest represents the data structure for creating/updating market ratings
 type MarketRatingRequest struct {
 	OverallRating float64            `json:"overall_rating"`
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// BenchmarkTicker is the index that deltas are beta-weighted to
+const BenchmarkTicker = "SPY"
+
+// Directional bias of a group of positions compared with its rating
+const (
+	BiasAligned = "aligned"
+	BiasOpposed = "opposed"
+	BiasNeutral = "neutral"
+	BiasUnrated = "unrated"
+)
+
+// Beta is an underlying's beta against the benchmark
+type Beta struct {
+	Ticker    string    `json:"ticker"`
+	Beta      float64   `json:"beta"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Quote holds the market inputs for one underlying. Volatility is an
+// annualized decimal (0.25 = 25%).
+type Quote struct {
+	Price      float64 `json:"price"`
+	Volatility float64 `json:"volatility"`
+}
+
+// PortfolioGreeksRequest holds the quotes used to value every open trade.
+// BenchmarkPrice defaults to the SPY quote when zero.
+type PortfolioGreeksRequest struct {
+	Quotes         map[string]Quote `json:"quotes"`
+	Rate           float64          `json:"rate"`
+	BenchmarkPrice float64          `json:"benchmark_price"`
+}
+
+// GreeksBucket sums the Greeks of a group of open positions, with the units
+// of PositionGreeks: Delta in shares of the underlying, Gamma in shares per
+// $1 move, Theta and Vega in dollars. BetaWeightedDelta is in benchmark
+// shares. Rating and Bias are set for sectors and the portfolio total.
+type GreeksBucket struct {
+	Label             string   `json:"label"`
+	Trades            int      `json:"trades"`
+	Delta             float64  `json:"delta"`
+	Gamma             float64  `json:"gamma"`
+	Theta             float64  `json:"theta"`
+	Vega              float64  `json:"vega"`
+	BetaWeightedDelta float64  `json:"beta_weighted_delta"`
+	Rating            *float64 `json:"rating,omitempty"`
+	Bias              string   `json:"bias,omitempty"`
+}
+
+// PortfolioGreeks is the risk of every open trade, in total and grouped by
+// sector, strategy category and the week each leg expires
+type PortfolioGreeks struct {
+	AsOf           time.Time      `json:"as_of"`
+	Benchmark      string         `json:"benchmark"`
+	BenchmarkPrice float64        `json:"benchmark_price"`
+	Total          GreeksBucket   `json:"total"`
+	BySector       []GreeksBucket `json:"by_sector"`
+	ByCategory     []GreeksBucket `json:"by_category"`
+	ByExpiration   []GreeksBucket `json:"by_expiration"`
+	// Unpriced lists tickers with open trades but no usable quote; their
+	// trades are left out
+	Unpriced []string `json:"unpriced"`
+	// DefaultBeta lists tickers with no stored beta, weighted at 1.0
+	DefaultBeta []string `json:"default_beta"`
+}
+
+// BiasOf compares the sign of a beta-weighted delta with a rating. Less
+// than one benchmark share either way counts as neutral.
+func BiasOf(rating *float64, betaWeightedDelta float64) string {
+	switch {
+	case rating == nil:
+		return BiasUnrated
+	case *rating == 0 || (betaWeightedDelta > -1 && betaWeightedDelta < 1):
+		return BiasNeutral
+	case (*rating > 0) == (betaWeightedDelta > 0):
+		return BiasAligned
+	default:
+		return BiasOpposed
+	}
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Sort keys accepted by TradeQuery
+const (
+	SortEntryDate      = "entry_date"
+	SortExpirationDate = "expiration_date"
+	SortTicker         = "ticker"
+	SortSector         = "sector"
+	SortStrategy       = "strategy_type"
+	SortStatus         = "status"
+	SortTargetPrice    = "target_price"
+	SortStopLoss       = "stop_loss"
+	SortCreatedAt      = "created_at"
+	SortUpdatedAt      = "updated_at"
+)
+
+// DefaultQueryLimit and MaxQueryLimit bound the size of a TradePage
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+// TradeQuery selects a page of trades. List fields match any of their
+// values and empty lists match everything; zero dates and nil prices leave
+// that bound open. Date bounds are inclusive.
+type TradeQuery struct {
+	Tickers    []string `json:"tickers,omitempty"`
+	Sectors    []string `json:"sectors,omitempty"`
+	Strategies []string `json:"strategies,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	Statuses   []string `json:"statuses,omitempty"`
+
+	EntryFrom      time.Time `json:"entry_from"`
+	EntryTo        time.Time `json:"entry_to"`
+	ExpirationFrom time.Time `json:"expiration_from"`
+	ExpirationTo   time.Time `json:"expiration_to"`
+	// ActiveFrom and ActiveTo match trades whose entry-to-expiration span
+	// overlaps the range, as the calendar grid shows them
+	ActiveFrom time.Time `json:"active_from"`
+	ActiveTo   time.Time `json:"active_to"`
+
+	TargetPriceMin *float64 `json:"target_price_min,omitempty"`
+	TargetPriceMax *float64 `json:"target_price_max,omitempty"`
+	StopLossMin    *float64 `json:"stop_loss_min,omitempty"`
+	StopLossMax    *float64 `json:"stop_loss_max,omitempty"`
+	// StrikeMin and StrikeMax match trades with any leg struck in the range
+	StrikeMin *float64 `json:"strike_min,omitempty"`
+	StrikeMax *float64 `json:"strike_max,omitempty"`
+
+	// SortBy defaults to entry_date; ties are broken by trade ID
+	SortBy   string `json:"sort_by,omitempty"`
+	SortDesc bool   `json:"sort_desc"`
+	Limit    int    `json:"limit,omitempty"`
+	// Cursor continues from the NextCursor of a previous page with the same
+	// sort
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// TradePage is one page of a TradeQuery
+type TradePage struct {
+	Trades     []OptionsTrade `json:"trades"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
+
+// GetSortKeys returns the sort keys accepted by TradeQuery
+func GetSortKeys() []string {
+	return []string{
+		SortEntryDate,
+		SortExpirationDate,
+		SortTicker,
+		SortSector,
+		SortStrategy,
+		SortStatus,
+		SortTargetPrice,
+		SortStopLoss,
+		SortCreatedAt,
+		SortUpdatedAt,
+	}
+}
+
+// ValidateTradeQuery validates a trade query
+func ValidateTradeQuery(q TradeQuery) error {
+	if q.SortBy != "" && !slices.Contains(GetSortKeys(), q.SortBy) {
+		return fmt.Errorf("invalid sort key: %s", q.SortBy)
+	}
+	for _, status := range q.Statuses {
+		if !slices.Contains(GetValidStatuses(), status) {
+			return fmt.Errorf("invalid status: %s", status)
+		}
+	}
+	if q.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+
+	ranges := []struct {
+		name     string
+		min, max *float64
+	}{
+		{"target price", q.TargetPriceMin, q.TargetPriceMax},
+		{"stop loss", q.StopLossMin, q.StopLossMax},
+		{"strike", q.StrikeMin, q.StrikeMax},
+	}
+	for _, r := range ranges {
+		if r.min != nil && r.max != nil && *r.min > *r.max {
+			return fmt.Errorf("%s minimum is above its maximum", r.name)
+		}
+	}
+
+	dates := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{"entry", q.EntryFrom, q.EntryTo},
+		{"expiration", q.ExpirationFrom, q.ExpirationTo},
+		{"active", q.ActiveFrom, q.ActiveTo},
+	}
+	for _, d := range dates {
+		if !d.from.IsZero() && !d.to.IsZero() && d.to.Before(d.from) {
+			return fmt.Errorf("%s date range ends before it starts", d.name)
+		}
+	}
+	return nil
+}
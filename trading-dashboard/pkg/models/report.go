@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// BasketReport is the weekly review of the basket: the market view, the open
+// positions by sector, what expires next and what was realized in the range
+type BasketReport struct {
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	GeneratedAt time.Time `json:"generated_at"`
+	// Rating is the snapshot in effect at the end of the range, if any
+	Rating *MarketRating  `json:"rating,omitempty"`
+	Active []SectorTrades `json:"active"`
+	// Expiring lists open trades expiring in the seven days after the range
+	Expiring     []OptionsTrade `json:"expiring"`
+	ExpiringFrom time.Time      `json:"expiring_from"`
+	ExpiringTo   time.Time      `json:"expiring_to"`
+	PnL          PnLSummary     `json:"pnl"`
+}
+
+// SectorTrades is the open trades in one sector with the sector's rating
+type SectorTrades struct {
+	Sector string         `json:"sector"`
+	Rating *float64       `json:"rating,omitempty"`
+	Trades []OptionsTrade `json:"trades"`
+}
+
+// ReportSummary describes a rendered report file
+type ReportSummary struct {
+	Path         string    `json:"path"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	Pages        int       `json:"pages"`
+	ActiveTrades int       `json:"active_trades"`
+	Expiring     int       `json:"expiring"`
+	RealizedPnL  float64   `json:"realized_pnl"`
+}
+
+// ReportWeek returns the Monday-to-Sunday week containing t, in UTC
+func ReportWeek(t time.Time) (time.Time, time.Time) {
+	y, m, d := t.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	start := day.AddDate(0, 0, -offset)
+	return start, start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
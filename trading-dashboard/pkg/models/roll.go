@@ -0,0 +1,26 @@
+package models
+
+// RollRequest describes rolling an open trade into a new one. The old
+// position is closed and the new one opened in a single operation, and the
+// new trade is linked to the old one as its parent.
+type RollRequest struct {
+	// Close is the closing fill of the old position; a zero Quantity closes
+	// the entire remaining position. It must leave nothing open, and may only
+	// be omitted for trades entered without fills.
+	Close *FillRequest `json:"close,omitempty"`
+	// Trade is the new position. An empty ticker, sector or strategy type is
+	// taken from the old trade.
+	Trade TradeRequest `json:"trade"`
+	// Open is the opening fill of the new position
+	Open *FillRequest `json:"open,omitempty"`
+}
+
+// RollChain summarizes the P&L of an original trade and every trade rolled
+// out of it, so the whole campaign can be judged as one position
+type RollChain struct {
+	RootTradeID   int64      `json:"root_trade_id"`
+	Trades        []TradePnL `json:"trades"`
+	RealizedPnL   float64    `json:"realized_pnl"`
+	UnrealizedPnL *float64   `json:"unrealized_pnl,omitempty"`
+	Fees          float64    `json:"fees"`
+}
@@ -0,0 +1,122 @@
+package models
+
+import "fmt"
+
+// Rule severities. A blocking rule stops the trade from being created, a
+// warning lets it through and reports the violation, and off skips the rule.
+const (
+	SeverityBlock = "block"
+	SeverityWarn  = "warn"
+	SeverityOff   = "off"
+)
+
+// Trade rule identifiers reported on violations
+const (
+	RuleMaxOpenPerSector    = "max_open_per_sector"
+	RuleRatingDirection     = "rating_direction"
+	RuleDaysToExpiration    = "days_to_expiration"
+	RuleDuplicateTickerWeek = "duplicate_ticker_week"
+)
+
+// SettingTradeRules is the settings key holding the trade rules as JSON
+const SettingTradeRules = "trade_rules"
+
+// MaxOpenRule limits the open trades in one sector, counting the new trade
+type MaxOpenRule struct {
+	Severity string `json:"severity"`
+	Max      int    `json:"max"`
+}
+
+// RatingDirectionRule refuses bullish trades in sectors rated below
+// -Threshold and bearish trades in sectors rated above +Threshold
+type RatingDirectionRule struct {
+	Severity  string  `json:"severity"`
+	Threshold float64 `json:"threshold"`
+}
+
+// DaysToExpirationRule requires the days from entry to expiration to be
+// between Min and Max inclusive
+type DaysToExpirationRule struct {
+	Severity string `json:"severity"`
+	Min      int    `json:"min"`
+	Max      int    `json:"max"`
+}
+
+// SeverityRule is a rule with no parameters
+type SeverityRule struct {
+	Severity string `json:"severity"`
+}
+
+// TradeRules is the playbook checked before a trade is created
+type TradeRules struct {
+	MaxOpenPerSector    MaxOpenRule          `json:"max_open_per_sector"`
+	RatingDirection     RatingDirectionRule  `json:"rating_direction"`
+	DaysToExpiration    DaysToExpirationRule `json:"days_to_expiration"`
+	DuplicateTickerWeek SeverityRule         `json:"duplicate_ticker_week"`
+}
+
+// DefaultTradeRules returns the rules used until others are saved
+func DefaultTradeRules() TradeRules {
+	return TradeRules{
+		MaxOpenPerSector:    MaxOpenRule{Severity: SeverityBlock, Max: 5},
+		RatingDirection:     RatingDirectionRule{Severity: SeverityBlock, Threshold: 1},
+		DaysToExpiration:    DaysToExpirationRule{Severity: SeverityWarn, Min: 14, Max: 60},
+		DuplicateTickerWeek: SeverityRule{Severity: SeverityWarn},
+	}
+}
+
+// Validate checks the severities and limits of every rule
+func (r TradeRules) Validate() error {
+	severities := []struct{ rule, severity string }{
+		{RuleMaxOpenPerSector, r.MaxOpenPerSector.Severity},
+		{RuleRatingDirection, r.RatingDirection.Severity},
+		{RuleDaysToExpiration, r.DaysToExpiration.Severity},
+		{RuleDuplicateTickerWeek, r.DuplicateTickerWeek.Severity},
+	}
+	for _, s := range severities {
+		if s.severity != SeverityBlock && s.severity != SeverityWarn && s.severity != SeverityOff {
+			return fmt.Errorf("%s: invalid severity %q", s.rule, s.severity)
+		}
+	}
+	if r.MaxOpenPerSector.Max < 1 {
+		return fmt.Errorf("%s: max must be at least 1", RuleMaxOpenPerSector)
+	}
+	if r.RatingDirection.Threshold < 0 || r.RatingDirection.Threshold > 3 {
+		return fmt.Errorf("%s: threshold must be between 0 and 3", RuleRatingDirection)
+	}
+	if r.DaysToExpiration.Min < 0 || r.DaysToExpiration.Max < r.DaysToExpiration.Min {
+		return fmt.Errorf("%s: min must be at least 0 and no more than max", RuleDaysToExpiration)
+	}
+	return nil
+}
+
+// RuleViolation is one rule a proposed trade breaks
+type RuleViolation struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// RuleCheck lists every rule a proposed trade breaks. Blocked is true when
+// any of them is a blocking rule.
+type RuleCheck struct {
+	Violations []RuleViolation `json:"violations"`
+	Blocked    bool            `json:"blocked"`
+}
+
+// StrategyDirection returns the market direction of a seeded strategy type,
+// or "" for strategies it does not know
+func StrategyDirection(strategy string) string {
+	switch strategy {
+	case "Long Call", "Covered Call", "Cash-Secured Put", "Bull Put Spread",
+		"Bull Call Spread", "Call Ratio Backspread":
+		return DirectionBullish
+	case "Long Put", "Bear Call Spread", "Bear Put Spread", "Put Ratio Backspread":
+		return DirectionBearish
+	case "Iron Butterfly", "Iron Condor", "Long Put Butterfly", "Long Call Butterfly",
+		"Calendar Call Spread", "Calendar Put Spread", "Straddle", "Strangle":
+		return DirectionNeutral
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Limits on a stress test request
+const (
+	MaxScenarioSteps       = 41
+	MaxScenarioDaysForward = 3650
+)
+
+// DefaultPriceMoves and DefaultVolShifts are the grid axes used when a
+// scenario request leaves them empty
+var (
+	DefaultPriceMoves = []float64{-0.2, -0.1, -0.05, 0, 0.05, 0.1, 0.2}
+	DefaultVolShifts  = []float64{-0.1, 0, 0.1, 0.2}
+)
+
+// SectorShock moves every underlying in one sector on top of the grid move.
+// PriceMove is a fraction of the price (-0.3 = down 30%) and VolShift is in
+// volatility points as a decimal (0.15 = IV up 15 points).
+type SectorShock struct {
+	Sector    string  `json:"sector"`
+	PriceMove float64 `json:"price_move"`
+	VolShift  float64 `json:"vol_shift"`
+}
+
+// ScenarioRequest describes a stress test of the open trades. Every
+// underlying is moved by each PriceMoves x VolShifts pair of the grid, plus
+// the shock of its sector, and valued DaysForward days from now. A combined
+// price move below -100% is rejected and shifted volatility is floored at
+// zero.
+type ScenarioRequest struct {
+	Quotes       map[string]Quote `json:"quotes"`
+	Rate         float64          `json:"rate"`
+	PriceMoves   []float64        `json:"price_moves"`
+	VolShifts    []float64        `json:"vol_shifts"`
+	DaysForward  int              `json:"days_forward"`
+	SectorShocks []SectorShock    `json:"sector_shocks"`
+}
+
+// ScenarioGrid holds the P&L of a group of trades in each scenario, indexed
+// as PnL[price move][vol shift], with the worst cell picked out
+type ScenarioGrid struct {
+	Label          string      `json:"label"`
+	Trades         int         `json:"trades"`
+	PnL            [][]float64 `json:"pnl"`
+	WorstPnL       float64     `json:"worst_pnl"`
+	WorstPriceMove float64     `json:"worst_price_move"`
+	WorstVolShift  float64     `json:"worst_vol_shift"`
+}
+
+// ScenarioResult is the stress test of the open trades, for the whole book
+// and by sector. WorstSector names the sector with the largest loss in any
+// single scenario and is empty when no sector loses money.
+type ScenarioResult struct {
+	AsOf         time.Time      `json:"as_of"`
+	ValuedAt     time.Time      `json:"valued_at"`
+	DaysForward  int            `json:"days_forward"`
+	PriceMoves   []float64      `json:"price_moves"`
+	VolShifts    []float64      `json:"vol_shifts"`
+	SectorShocks []SectorShock  `json:"sector_shocks"`
+	Total        ScenarioGrid   `json:"total"`
+	BySector     []ScenarioGrid `json:"by_sector"`
+	WorstSector  string         `json:"worst_sector"`
+	// Unpriced lists tickers with open trades but no usable quote; their
+	// trades are left out
+	Unpriced []string `json:"unpriced"`
+}
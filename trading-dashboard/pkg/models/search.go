@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Markers around the matched terms in a search snippet
+const (
+	SnippetOpen  = "[["
+	SnippetClose = "]]"
+)
+
+// DefaultSearchLimit and MaxSearchLimit bound the results of a trade search
+const (
+	DefaultSearchLimit = 50
+	MaxSearchLimit     = 500
+)
+
+// TradeSearchFilters narrows a trade search. Empty fields and zero dates
+// match everything; From and To bound the entry date.
+type TradeSearchFilters struct {
+	Status       string    `json:"status,omitempty"`
+	StrategyType string    `json:"strategy_type,omitempty"`
+	Sector       string    `json:"sector,omitempty"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Limit        int       `json:"limit,omitempty"`
+}
+
+// TradeSearchResult is a trade matching a search with an excerpt of the
+// matching text. Results are ordered best match first.
+type TradeSearchResult struct {
+	Trade   OptionsTrade `json:"trade"`
+	Snippet string       `json:"snippet"`
+	Rank    float64      `json:"rank"`
+}
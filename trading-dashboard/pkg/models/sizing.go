@@ -0,0 +1,72 @@
+package models
+
+import "math"
+
+// Direction of a strategy's exposure to its underlying
+const (
+	DirectionBullish = "bullish"
+	DirectionBearish = "bearish"
+	DirectionNeutral = "neutral"
+)
+
+// Settings keys holding the sizing defaults
+const (
+	SettingAccountEquity  = "sizing.account_equity"
+	SettingMaxRiskPercent = "sizing.max_risk_percent"
+)
+
+// DefaultMaxRiskPercent is the share of equity a full-size trade may risk
+// when no setting is stored
+const DefaultMaxRiskPercent = 2.0
+
+// SizingRequest describes a proposed trade to size. Legs describe one unit
+// of the spread, so their quantities give its ratio (a 1x2 backspread has
+// quantities 1 and 2). Direction is inferred from the legs' payoff when
+// empty. AccountEquity and MaxRiskPercent fall back to the stored settings
+// when zero. Volatility and Rate are only needed for legs with more than one
+// expiration.
+type SizingRequest struct {
+	Sector         string  `json:"sector"`
+	Legs           []Leg   `json:"legs"`
+	Direction      string  `json:"direction"`
+	AccountEquity  float64 `json:"account_equity"`
+	MaxRiskPercent float64 `json:"max_risk_percent"`
+	Volatility     float64 `json:"volatility"`
+	Rate           float64 `json:"rate"`
+}
+
+// SizingResult is the number of units of a spread that fit the risk budget.
+// RatingSource is "sector", "overall" when the sector is unrated and the
+// overall rating is used instead, or "" when there is no rating at all.
+// Reason explains a reduced or zero size.
+type SizingResult struct {
+	Sector            string   `json:"sector"`
+	Rating            *float64 `json:"rating,omitempty"`
+	RatingSource      string   `json:"rating_source"`
+	Direction         string   `json:"direction"`
+	DirectionInferred bool     `json:"direction_inferred"`
+	AccountEquity     float64  `json:"account_equity"`
+	MaxRiskPercent    float64  `json:"max_risk_percent"`
+	MaxRisk           float64  `json:"max_risk"`
+	Scale             float64  `json:"scale"`
+	RiskBudget        float64  `json:"risk_budget"`
+	RiskPerUnit       float64  `json:"risk_per_unit"`
+	Contracts         int      `json:"contracts"`
+	RiskUsed          float64  `json:"risk_used"`
+	Reason            string   `json:"reason,omitempty"`
+}
+
+// ConvictionScale maps the strength of a rating to a share of full size:
+// nothing at 0, half at ±1 and full at ±3, linear in between
+func ConvictionScale(rating float64) float64 {
+	r := math.Min(math.Abs(rating), 3)
+	if r <= 1 {
+		return r / 2
+	}
+	return 0.5 + (r-1)/4
+}
+
+// IsValidDirection reports whether d is a known direction
+func IsValidDirection(d string) bool {
+	return d == DirectionBullish || d == DirectionBearish || d == DirectionNeutral
+}
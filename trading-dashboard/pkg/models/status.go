@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// StatusChange records a single status transition of a trade
+type StatusChange struct {
+	ID         int64     `json:"id"`
+	TradeID    int64     `json:"trade_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// Reasons recorded for automatic transitions
+const (
+	ReasonExpired  = "passed expiration at market close"
+	ReasonClosed   = "closing fill recorded"
+	ReasonImported = "closed by broker import"
+	ReasonAdjusted = "legs closed by broker import"
+)
+
+// statusTransitions lists the statuses each status may move to. Closed and
+// expired trades may be reopened to correct a mistake; an expired trade can
+// still turn out to have been assigned or exercised.
+var statusTransitions = map[string][]string{
+	StatusPlanned:   {StatusActive},
+	StatusActive:    {StatusAdjusted, StatusRolled, StatusAssigned, StatusExercised, StatusClosed, StatusExpired},
+	StatusAdjusted:  {StatusRolled, StatusAssigned, StatusExercised, StatusClosed, StatusExpired},
+	StatusExpired:   {StatusAssigned, StatusExercised, StatusActive},
+	StatusClosed:    {StatusActive},
+	StatusRolled:    {},
+	StatusAssigned:  {},
+	StatusExercised: {},
+}
+
+// CanTransition reports whether a trade may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStatusTransitions returns the legal next statuses for every status
+func GetStatusTransitions() map[string][]string {
+	transitions := make(map[string][]string, len(statusTransitions))
+	for from, to := range statusTransitions {
+		transitions[from] = append([]string{}, to...)
+	}
+	return transitions
+}
+
+// IsOpenStatus reports whether a status describes a live position: active
+// or adjusted
+func IsOpenStatus(status string) bool {
+	return status == StatusActive || status == StatusAdjusted
+}
+
+// IsFinishedStatus reports whether a status ends a position's life
+func IsFinishedStatus(status string) bool {
+	return status != StatusPlanned && !IsOpenStatus(status)
+}
+
+// IsPastExpiration reports whether a trade's expiration has passed the
+// exchange close as of now
+func (t OptionsTrade) IsPastExpiration(now time.Time) bool {
+	return !now.Before(ExpirationCutoff(t.ExpirationDate))
+}
@@ -2,7 +2,10 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"trading-dashboard/pkg/occ"
 )
 
 // OptionsTrade represents an options trading position
@@ -17,8 +20,14 @@ type OptionsTrade struct {
 	StopLoss       *float64  `json:"stop_loss,omitempty"`
 	Status         string    `json:"status"`
 	Notes          string    `json:"notes"`
+	Legs           []Leg     `json:"legs"`
+	ExternalID     string    `json:"external_id,omitempty"`
+	ParentTradeID  *int64    `json:"parent_trade_id,omitempty"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+	// Warnings lists the trade rules a newly created trade breaks without
+	// being blocked; it is not stored
+	Warnings []RuleViolation `json:"warnings,omitempty"`
 }
 
 // TradeRequest represents the data structure for creating/updating trades
@@ -31,6 +40,43 @@ type TradeRequest struct {
 	TargetPrice    *float64  `json:"target_price,omitempty"`
 	StopLoss       *float64  `json:"stop_loss,omitempty"`
 	Notes          string    `json:"notes"`
+	// Legs replaces the trade's legs when non-nil; a nil slice leaves
+	// existing legs untouched on update
+	Legs []LegRequest `json:"legs,omitempty"`
+	// Status sets the initial status on create: planned or active (the
+	// default). It is ignored on update; use UpdateTradeStatus instead.
+	Status string `json:"status,omitempty"`
+	// ExternalID is set by the broker importer for de-duplication and is
+	// ignored on update
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// Leg represents a single option (or stock) position within a trade
+type Leg struct {
+	ID             int64     `json:"id"`
+	TradeID        int64     `json:"trade_id"`
+	OptionType     string    `json:"option_type"`
+	Side           string    `json:"side"`
+	Strike         float64   `json:"strike"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	Quantity       int       `json:"quantity"`
+	Premium        float64   `json:"premium"`
+	Symbol         string    `json:"symbol,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// LegRequest represents the data structure for creating/updating a trade leg.
+// A zero ExpirationDate defaults to the trade's expiration date. When Symbol
+// holds an OCC option symbol, the option type, strike and expiration are
+// taken from it.
+type LegRequest struct {
+	OptionType     string    `json:"option_type"`
+	Side           string    `json:"side"`
+	Strike         float64   `json:"strike"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	Quantity       int       `json:"quantity"`
+	Premium        float64   `json:"premium"`
+	Symbol         string    `json:"symbol,omitempty"`
 }
 
 // StrategyType represents an options trading strategy
@@ -42,13 +88,33 @@ type StrategyType struct {
 	ColorHex    string `json:"color_hex"`
 }
 
-// TradeStatus represents possible trade statuses
+// TradeStatus represents the lifecycle states of a trade. "active" is the
+// open state; see CanTransition for the legal moves between states.
+const (
+	StatusPlanned   = "planned"
+	StatusActive    = "active"
+	StatusAdjusted  = "adjusted"
+	StatusRolled    = "rolled"
+	StatusAssigned  = "assigned"
+	StatusExercised = "exercised"
+	StatusClosed    = "closed"
+	StatusExpired   = "expired"
+)
+
+// Leg option types and sides. Stock legs carry the share price in Premium
+// and the share count in Quantity.
 const (
-	StatusActive  = "active"
-	StatusClosed  = "closed"
-	StatusExpired = "expired"
+	OptionTypeCall  = "call"
+	OptionTypePut   = "put"
+	OptionTypeStock = "stock"
+
+	SideBuy  = "buy"
+	SideSell = "sell"
 )
 
+// ContractMultiplier is the number of shares controlled by one option contract
+const ContractMultiplier = 100
+
 // StrategyCategory represents strategy categories
 const (
 	CategoryBasic           = "Basic"
@@ -81,12 +147,118 @@ func ValidateTradeRequest(req TradeRequest) error {
 	if req.ExpirationDate.Before(req.EntryDate) {
 		return fmt.Errorf("expiration date must be after entry date")
 	}
+	if req.Status != "" && req.Status != StatusPlanned && req.Status != StatusActive {
+		return fmt.Errorf("new trades must start as %s or %s", StatusPlanned, StatusActive)
+	}
+	for i, leg := range req.Legs {
+		if err := ValidateLegRequest(leg, req.EntryDate); err != nil {
+			return fmt.Errorf("leg %d: %w", i+1, err)
+		}
+	}
 	return nil
 }
 
+// ValidateLegRequest validates a single trade leg
+func ValidateLegRequest(leg LegRequest, entryDate time.Time) error {
+	switch leg.OptionType {
+	case OptionTypeCall, OptionTypePut:
+		if leg.Strike <= 0 {
+			return fmt.Errorf("strike must be greater than zero")
+		}
+		if !leg.ExpirationDate.IsZero() && leg.ExpirationDate.Before(entryDate) {
+			return fmt.Errorf("expiration date must be after entry date")
+		}
+	case OptionTypeStock:
+	default:
+		return fmt.Errorf("invalid option type: %s", leg.OptionType)
+	}
+	if leg.Side != SideBuy && leg.Side != SideSell {
+		return fmt.Errorf("invalid side: %s", leg.Side)
+	}
+	if leg.Quantity <= 0 {
+		return fmt.Errorf("quantity must be greater than zero")
+	}
+	if leg.Premium < 0 {
+		return fmt.Errorf("premium cannot be negative")
+	}
+	return nil
+}
+
+// ResolveLegSymbols fills the option type, strike and expiration of every
+// leg entered by OCC symbol. The symbol root must match the trade's ticker,
+// and any fields already set on the leg must agree with the symbol.
+func ResolveLegSymbols(req *TradeRequest) error {
+	for i := range req.Legs {
+		leg := &req.Legs[i]
+		if leg.Symbol == "" {
+			continue
+		}
+
+		sym, err := occ.Parse(leg.Symbol)
+		if err != nil {
+			return fmt.Errorf("leg %d: %w", i+1, err)
+		}
+		if !occ.RootMatches(sym.Root, req.Ticker) {
+			return fmt.Errorf("leg %d: symbol root %s does not match ticker %s", i+1, sym.Root, req.Ticker)
+		}
+		if leg.OptionType != "" && leg.OptionType != sym.OptionType {
+			return fmt.Errorf("leg %d: option type %s conflicts with symbol %s", i+1, leg.OptionType, leg.Symbol)
+		}
+		if leg.Strike != 0 && leg.Strike != sym.Strike {
+			return fmt.Errorf("leg %d: strike %g conflicts with symbol %s", i+1, leg.Strike, leg.Symbol)
+		}
+		if !leg.ExpirationDate.IsZero() && !sameDay(leg.ExpirationDate, sym.Expiration) {
+			return fmt.Errorf("leg %d: expiration %s conflicts with symbol %s", i+1, leg.ExpirationDate.Format("2006-01-02"), leg.Symbol)
+		}
+
+		leg.OptionType = sym.OptionType
+		leg.Strike = sym.Strike
+		leg.ExpirationDate = sym.Expiration
+		leg.Symbol = sym.String()
+	}
+	return nil
+}
+
+// OCCSymbol returns the OCC symbol of an option leg on the given underlying,
+// or "" for stock legs
+func (l Leg) OCCSymbol(ticker string) string {
+	if l.OptionType == OptionTypeStock {
+		return ""
+	}
+	return occ.Symbol{
+		Root:       strings.NewReplacer(".", "", "/", "").Replace(ticker),
+		Expiration: l.ExpirationDate,
+		OptionType: l.OptionType,
+		Strike:     l.Strike,
+	}.String()
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// Multiplier returns the number of shares represented by one unit of the leg
+func (l Leg) Multiplier() float64 {
+	if l.OptionType == OptionTypeStock {
+		return 1
+	}
+	return ContractMultiplier
+}
+
 // GetValidStatuses returns all valid trade statuses
 func GetValidStatuses() []string {
-	return []string{StatusActive, StatusClosed, StatusExpired}
+	return []string{
+		StatusPlanned,
+		StatusActive,
+		StatusAdjusted,
+		StatusRolled,
+		StatusAssigned,
+		StatusExercised,
+		StatusClosed,
+		StatusExpired,
+	}
 }
 
 // GetValidCategories returns all valid strategy categories
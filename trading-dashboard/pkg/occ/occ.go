@@ -0,0 +1,135 @@
+package occ
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Option types, matching the leg option types in pkg/models
+const (
+	Call = "call"
+	Put  = "put"
+)
+
+// SymbolLength is the length of a padded OCC option symbol
+const SymbolLength = 21
+
+// maxRootLength is the width of the padded root field
+const maxRootLength = 6
+
+// maxStrike is the largest strike the eight-digit strike field can hold
+const maxStrike = 99999.999
+
+// Symbol is a decoded OCC option symbol such as "AAPL  250117C00150000":
+// the root padded to six characters, the expiration as YYMMDD, C or P, and
+// the strike in thousandths of a dollar padded to eight digits
+type Symbol struct {
+	Root       string    `json:"root"`
+	Expiration time.Time `json:"expiration"`
+	OptionType string    `json:"option_type"`
+	Strike     float64   `json:"strike"`
+}
+
+// Parse decodes an OCC option symbol. The compact form without root padding
+// ("AAPL250117C00150000") is also accepted. The expiration is returned as
+// midnight UTC.
+func Parse(s string) (Symbol, error) {
+	var sym Symbol
+
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 16 || len(s) > SymbolLength {
+		return sym, fmt.Errorf("invalid OCC symbol %q: expected up to %d characters", s, SymbolLength)
+	}
+
+	// The last 15 characters are fixed width: YYMMDD, C/P, 8-digit strike
+	tail := s[len(s)-15:]
+	sym.Root = strings.TrimSpace(s[:len(s)-15])
+	if sym.Root == "" || len(sym.Root) > maxRootLength {
+		return sym, fmt.Errorf("invalid OCC symbol %q: root must be 1-%d characters", s, maxRootLength)
+	}
+
+	expiration, err := time.Parse("060102", tail[:6])
+	if err != nil {
+		return sym, fmt.Errorf("invalid OCC symbol %q: bad expiration %q", s, tail[:6])
+	}
+	sym.Expiration = expiration
+
+	switch tail[6] {
+	case 'C':
+		sym.OptionType = Call
+	case 'P':
+		sym.OptionType = Put
+	default:
+		return sym, fmt.Errorf("invalid OCC symbol %q: option type must be C or P", s)
+	}
+
+	digits := tail[7:]
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return sym, fmt.Errorf("invalid OCC symbol %q: bad strike %q", s, digits)
+		}
+	}
+	thousandths, err := strconv.Atoi(digits)
+	if err != nil || thousandths == 0 {
+		return sym, fmt.Errorf("invalid OCC symbol %q: bad strike %q", s, digits)
+	}
+	sym.Strike = float64(thousandths) / 1000
+
+	return sym, nil
+}
+
+// Format encodes a symbol in the padded 21-character OCC form
+func Format(sym Symbol) (string, error) {
+	root := strings.ToUpper(strings.TrimSpace(sym.Root))
+	if root == "" || len(root) > maxRootLength {
+		return "", fmt.Errorf("root must be 1-%d characters: %q", maxRootLength, sym.Root)
+	}
+	if sym.Expiration.IsZero() {
+		return "", fmt.Errorf("expiration is required")
+	}
+
+	var kind byte
+	switch sym.OptionType {
+	case Call:
+		kind = 'C'
+	case Put:
+		kind = 'P'
+	default:
+		return "", fmt.Errorf("invalid option type: %s", sym.OptionType)
+	}
+
+	if sym.Strike <= 0 || sym.Strike > maxStrike {
+		return "", fmt.Errorf("strike out of range: %g", sym.Strike)
+	}
+	thousandths := int(math.Round(sym.Strike * 1000))
+
+	return fmt.Sprintf("%-6s%s%c%08d", root, sym.Expiration.Format("060102"), kind, thousandths), nil
+}
+
+// String returns the padded OCC form, or "" if the symbol is incomplete
+func (s Symbol) String() string {
+	formatted, err := Format(s)
+	if err != nil {
+		return ""
+	}
+	return formatted
+}
+
+// RootMatches reports whether an OCC root refers to the given ticker. OCC
+// roots drop share-class punctuation, so "BRKB" matches "BRK.B" and "BRK/B".
+func RootMatches(root, ticker string) bool {
+	return normalize(root) == normalize(ticker)
+}
+
+func normalize(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '/' || r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,288 @@
+package payoff
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/pricing"
+)
+
+const defaultSteps = 200
+
+// Point is a single sample of a P&L curve
+type Point struct {
+	Price float64 `json:"price"`
+	PnL   float64 `json:"pnl"`
+}
+
+// Options controls the price range sampled and the market inputs used to
+// value legs that are still open at the near-term expiration. A zero price
+// range is derived from the legs' strikes.
+type Options struct {
+	MinPrice   float64 `json:"min_price"`
+	MaxPrice   float64 `json:"max_price"`
+	Steps      int     `json:"steps"`
+	Volatility float64 `json:"volatility"`
+	Rate       float64 `json:"rate"`
+}
+
+// Analysis describes the payoff of a set of legs. Expiration values every leg
+// at its intrinsic value. When the legs have more than one expiration,
+// NearTerm values the position at the nearest expiration with later legs
+// priced by Black-Scholes, and the summary figures describe that curve.
+// Dollar amounts cover the whole position (quantity x multiplier). When a
+// side is unlimited its amount is the extreme within the sampled range.
+type Analysis struct {
+	Expiration         []Point    `json:"expiration"`
+	NearTerm           []Point    `json:"near_term,omitempty"`
+	NearTermDate       *time.Time `json:"near_term_date,omitempty"`
+	MaxProfit          float64    `json:"max_profit"`
+	MaxProfitUnlimited bool       `json:"max_profit_unlimited"`
+	MaxLoss            float64    `json:"max_loss"`
+	MaxLossUnlimited   bool       `json:"max_loss_unlimited"`
+	Breakevens         []float64  `json:"breakevens"`
+	NetPremium         float64    `json:"net_premium"`
+}
+
+// Analyze computes the payoff curves, maximum profit and loss, and breakeven
+// prices for a position
+func Analyze(legs []models.Leg, opts Options) (*Analysis, error) {
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("at least one leg is required")
+	}
+
+	minPrice, maxPrice := priceRange(legs, opts)
+	steps := opts.Steps
+	if steps <= 0 {
+		steps = defaultSteps
+	}
+
+	result := &Analysis{Breakevens: []float64{}}
+	for _, leg := range legs {
+		result.NetPremium -= sign(leg) * leg.Premium * float64(leg.Quantity) * leg.Multiplier()
+	}
+
+	// Kinks of the expiration curve are at the strikes, so sample those exactly
+	grid := samplePrices(minPrice, maxPrice, steps, strikes(legs))
+	atExpiry := func(price float64) float64 { return expirationPnL(legs, price) }
+	result.Expiration = curve(grid, atExpiry)
+
+	near, multi := nearestExpiration(legs)
+	if !multi {
+		summarize(result, append([]float64{0}, grid...), atExpiry, tailSlope(legs))
+		return result, nil
+	}
+
+	if opts.Volatility <= 0 {
+		return nil, fmt.Errorf("volatility is required to value positions with multiple expirations")
+	}
+
+	cutoff := models.ExpirationCutoff(near)
+	atNear := func(price float64) float64 {
+		return nearTermPnL(legs, price, cutoff, opts)
+	}
+	result.NearTerm = curve(grid, atNear)
+	result.NearTermDate = &near
+	summarize(result, append([]float64{minPrice / 100}, grid...), atNear, tailSlope(legs))
+
+	return result, nil
+}
+
+// expirationPnL values every leg at intrinsic value
+func expirationPnL(legs []models.Leg, price float64) float64 {
+	var pnl float64
+	for _, leg := range legs {
+		pnl += legPnL(leg, intrinsic(leg, price))
+	}
+	return pnl
+}
+
+// nearTermPnL values legs expiring at the cutoff at intrinsic value and
+// prices later legs with Black-Scholes
+func nearTermPnL(legs []models.Leg, price float64, cutoff time.Time, opts Options) float64 {
+	var pnl float64
+	for _, leg := range legs {
+		value := intrinsic(leg, price)
+		if leg.OptionType != models.OptionTypeStock {
+			remaining := pricing.YearsToExpiry(cutoff, models.ExpirationCutoff(leg.ExpirationDate))
+			if remaining > 0 && price > 0 {
+				if v, err := pricing.Price(pricing.Inputs{
+					OptionType:   leg.OptionType,
+					Spot:         price,
+					Strike:       leg.Strike,
+					TimeToExpiry: remaining,
+					Rate:         opts.Rate,
+					Volatility:   opts.Volatility,
+				}); err == nil {
+					value = v
+				}
+			}
+		}
+		pnl += legPnL(leg, value)
+	}
+	return pnl
+}
+
+// legPnL returns the P&L of a leg given its per-share value
+func legPnL(leg models.Leg, value float64) float64 {
+	return sign(leg) * (value - leg.Premium) * float64(leg.Quantity) * leg.Multiplier()
+}
+
+// intrinsic returns the per-share value of a leg at expiration
+func intrinsic(leg models.Leg, price float64) float64 {
+	switch leg.OptionType {
+	case models.OptionTypeCall:
+		return math.Max(price-leg.Strike, 0)
+	case models.OptionTypePut:
+		return math.Max(leg.Strike-price, 0)
+	default:
+		return price
+	}
+}
+
+// sign returns +1 for long legs and -1 for short legs
+func sign(leg models.Leg) float64 {
+	if leg.Side == models.SideSell {
+		return -1
+	}
+	return 1
+}
+
+// tailSlope is the P&L change per dollar of underlying once the price is
+// above every strike: calls and stock all move one-for-one
+func tailSlope(legs []models.Leg) float64 {
+	var slope float64
+	for _, leg := range legs {
+		if leg.OptionType == models.OptionTypeCall || leg.OptionType == models.OptionTypeStock {
+			slope += sign(leg) * float64(leg.Quantity) * leg.Multiplier()
+		}
+	}
+	return slope
+}
+
+// summarize fills in max profit, max loss and breakevens from the P&L at the
+// given prices (which must include every kink) and the slope beyond them
+func summarize(result *Analysis, prices []float64, pnlAt func(float64) float64, slope float64) {
+	sort.Float64s(prices)
+
+	result.MaxProfit = math.Inf(-1)
+	result.MaxLoss = math.Inf(1)
+	values := make([]float64, len(prices))
+	for i, price := range prices {
+		values[i] = pnlAt(price)
+		result.MaxProfit = math.Max(result.MaxProfit, values[i])
+		result.MaxLoss = math.Min(result.MaxLoss, values[i])
+	}
+
+	const eps = 1e-9
+	result.MaxProfitUnlimited = slope > eps
+	result.MaxLossUnlimited = slope < -eps
+
+	for i := 1; i < len(prices); i++ {
+		a, b := values[i-1], values[i]
+		switch {
+		case math.Abs(a) < eps && i == 1:
+			appendBreakeven(result, prices[i-1])
+		case math.Abs(b) < eps:
+			appendBreakeven(result, prices[i])
+		case (a < 0) != (b < 0):
+			appendBreakeven(result, prices[i-1]+(prices[i]-prices[i-1])*(-a)/(b-a))
+		}
+	}
+
+	// The curve may still cross zero beyond the last sampled price
+	last := values[len(values)-1]
+	if math.Abs(slope) > eps && math.Abs(last) > eps && (last < 0) == (slope > 0) {
+		appendBreakeven(result, prices[len(prices)-1]-last/slope)
+	}
+}
+
+// appendBreakeven records a breakeven price, skipping duplicates from flat
+// segments that sit exactly on zero
+func appendBreakeven(result *Analysis, price float64) {
+	price = math.Round(price*100) / 100
+	if n := len(result.Breakevens); n > 0 && math.Abs(result.Breakevens[n-1]-price) < 0.005 {
+		return
+	}
+	result.Breakevens = append(result.Breakevens, price)
+}
+
+// curve samples a P&L function over a price grid
+func curve(prices []float64, pnlAt func(float64) float64) []Point {
+	points := make([]Point, len(prices))
+	for i, price := range prices {
+		points[i] = Point{Price: price, PnL: pnlAt(price)}
+	}
+	return points
+}
+
+// samplePrices returns an evenly spaced grid with the extra prices merged in
+func samplePrices(minPrice, maxPrice float64, steps int, extra []float64) []float64 {
+	prices := make([]float64, 0, steps+1+len(extra))
+	step := (maxPrice - minPrice) / float64(steps)
+	for i := 0; i <= steps; i++ {
+		prices = append(prices, minPrice+step*float64(i))
+	}
+	for _, p := range extra {
+		if p > minPrice && p < maxPrice {
+			prices = append(prices, p)
+		}
+	}
+	sort.Float64s(prices)
+	return prices
+}
+
+// priceRange returns the sampled price range, defaulting to half the lowest
+// strike up to one and a half times the highest
+func priceRange(legs []models.Leg, opts Options) (float64, float64) {
+	if opts.MinPrice > 0 && opts.MaxPrice > opts.MinPrice {
+		return opts.MinPrice, opts.MaxPrice
+	}
+
+	ks := strikes(legs)
+	if len(ks) == 0 {
+		for _, leg := range legs {
+			ks = append(ks, leg.Premium)
+		}
+	}
+	lo, hi := ks[0], ks[0]
+	for _, k := range ks {
+		lo = math.Min(lo, k)
+		hi = math.Max(hi, k)
+	}
+	if hi <= 0 {
+		hi = 1
+	}
+	return lo * 0.5, hi * 1.5
+}
+
+// strikes returns the option strikes of the legs
+func strikes(legs []models.Leg) []float64 {
+	var ks []float64
+	for _, leg := range legs {
+		if leg.OptionType != models.OptionTypeStock && leg.Strike > 0 {
+			ks = append(ks, leg.Strike)
+		}
+	}
+	return ks
+}
+
+// nearestExpiration returns the earliest option expiration and whether the
+// legs span more than one expiration date
+func nearestExpiration(legs []models.Leg) (time.Time, bool) {
+	var near time.Time
+	dates := map[string]bool{}
+	for _, leg := range legs {
+		if leg.OptionType == models.OptionTypeStock {
+			continue
+		}
+		dates[leg.ExpirationDate.Format("2006-01-02")] = true
+		if near.IsZero() || leg.ExpirationDate.Before(near) {
+			near = leg.ExpirationDate
+		}
+	}
+	return near, len(dates) > 1
+}
@@ -0,0 +1,212 @@
+package payoff
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/pricing"
+)
+
+// TemplateSpec describes how to lay out a seeded strategy around the current
+// underlying price. Strikes are spaced Width apart from the strike nearest
+// Spot; premiums are Black-Scholes values at AsOf. FarExpiration is used by
+// calendar and diagonal spreads and defaults to 30 days after Expiration.
+type TemplateSpec struct {
+	Spot          float64   `json:"spot"`
+	Width         float64   `json:"width"`
+	Expiration    time.Time `json:"expiration"`
+	FarExpiration time.Time `json:"far_expiration"`
+	Volatility    float64   `json:"volatility"`
+	Rate          float64   `json:"rate"`
+	AsOf          time.Time `json:"as_of"`
+}
+
+// templateLeg is a leg of a strategy template. Offset is the strike distance
+// from the center strike in multiples of the width.
+type templateLeg struct {
+	optionType string
+	side       string
+	offset     float64
+	quantity   int
+	far        bool
+}
+
+// templates defines the leg structure of every seeded strategy type
+var templates = map[string][]templateLeg{
+	"Long Call": {{models.OptionTypeCall, models.SideBuy, 0, 1, false}},
+	"Long Put":  {{models.OptionTypePut, models.SideBuy, 0, 1, false}},
+
+	"Covered Call": {
+		{models.OptionTypeStock, models.SideBuy, 0, models.ContractMultiplier, false},
+		{models.OptionTypeCall, models.SideSell, 1, 1, false},
+	},
+	"Cash-Secured Put": {{models.OptionTypePut, models.SideSell, -1, 1, false}},
+
+	"Bull Put Spread": {
+		{models.OptionTypePut, models.SideSell, -1, 1, false},
+		{models.OptionTypePut, models.SideBuy, -2, 1, false},
+	},
+	"Bear Call Spread": {
+		{models.OptionTypeCall, models.SideSell, 1, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 2, 1, false},
+	},
+
+	"Iron Butterfly": {
+		{models.OptionTypePut, models.SideBuy, -1, 1, false},
+		{models.OptionTypePut, models.SideSell, 0, 1, false},
+		{models.OptionTypeCall, models.SideSell, 0, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 1, 1, false},
+	},
+	"Iron Condor": {
+		{models.OptionTypePut, models.SideBuy, -2, 1, false},
+		{models.OptionTypePut, models.SideSell, -1, 1, false},
+		{models.OptionTypeCall, models.SideSell, 1, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 2, 1, false},
+	},
+	"Long Put Butterfly": {
+		{models.OptionTypePut, models.SideBuy, -1, 1, false},
+		{models.OptionTypePut, models.SideSell, 0, 2, false},
+		{models.OptionTypePut, models.SideBuy, 1, 1, false},
+	},
+	"Long Call Butterfly": {
+		{models.OptionTypeCall, models.SideBuy, -1, 1, false},
+		{models.OptionTypeCall, models.SideSell, 0, 2, false},
+		{models.OptionTypeCall, models.SideBuy, 1, 1, false},
+	},
+
+	"Calendar Call Spread": {
+		{models.OptionTypeCall, models.SideSell, 0, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 0, 1, true},
+	},
+	"Calendar Put Spread": {
+		{models.OptionTypePut, models.SideSell, 0, 1, false},
+		{models.OptionTypePut, models.SideBuy, 0, 1, true},
+	},
+	"Diagonal Call Spread": {
+		{models.OptionTypeCall, models.SideSell, 1, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 0, 1, true},
+	},
+	"Diagonal Put Spread": {
+		{models.OptionTypePut, models.SideSell, -1, 1, false},
+		{models.OptionTypePut, models.SideBuy, 0, 1, true},
+	},
+
+	"Bull Call Spread": {
+		{models.OptionTypeCall, models.SideBuy, 0, 1, false},
+		{models.OptionTypeCall, models.SideSell, 1, 1, false},
+	},
+	"Bear Put Spread": {
+		{models.OptionTypePut, models.SideBuy, 0, 1, false},
+		{models.OptionTypePut, models.SideSell, -1, 1, false},
+	},
+
+	"Straddle": {
+		{models.OptionTypePut, models.SideBuy, 0, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 0, 1, false},
+	},
+	"Strangle": {
+		{models.OptionTypePut, models.SideBuy, -1, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 1, 1, false},
+	},
+
+	"Call Ratio Backspread": {
+		{models.OptionTypeCall, models.SideSell, 0, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 1, 2, false},
+	},
+	"Put Ratio Backspread": {
+		{models.OptionTypePut, models.SideSell, 0, 1, false},
+		{models.OptionTypePut, models.SideBuy, -1, 2, false},
+	},
+	"Put Broken Wing": {
+		{models.OptionTypePut, models.SideBuy, 1, 1, false},
+		{models.OptionTypePut, models.SideSell, 0, 2, false},
+		{models.OptionTypePut, models.SideBuy, -2, 1, false},
+	},
+	"Call Broken Wing": {
+		{models.OptionTypeCall, models.SideBuy, -1, 1, false},
+		{models.OptionTypeCall, models.SideSell, 0, 2, false},
+		{models.OptionTypeCall, models.SideBuy, 2, 1, false},
+	},
+	"Inverse Put Broken Wing": {
+		{models.OptionTypePut, models.SideSell, 1, 1, false},
+		{models.OptionTypePut, models.SideBuy, 0, 2, false},
+		{models.OptionTypePut, models.SideSell, -2, 1, false},
+	},
+	"Inverse Call Broken Wing": {
+		{models.OptionTypeCall, models.SideSell, -1, 1, false},
+		{models.OptionTypeCall, models.SideBuy, 0, 2, false},
+		{models.OptionTypeCall, models.SideSell, 2, 1, false},
+	},
+}
+
+// HasTemplate reports whether a strategy name has a leg template
+func HasTemplate(strategy string) bool {
+	_, ok := templates[strategy]
+	return ok
+}
+
+// BuildStrategyLegs lays out the legs of a seeded strategy type around the
+// spot price and prices them with Black-Scholes
+func BuildStrategyLegs(strategy string, spec TemplateSpec) ([]models.Leg, error) {
+	layout, ok := templates[strategy]
+	if !ok {
+		return nil, fmt.Errorf("no template for strategy: %s", strategy)
+	}
+	if spec.Spot <= 0 {
+		return nil, fmt.Errorf("spot price must be greater than zero")
+	}
+	if spec.Width <= 0 {
+		return nil, fmt.Errorf("strike width must be greater than zero")
+	}
+	if spec.Expiration.IsZero() {
+		return nil, fmt.Errorf("expiration date is required")
+	}
+	if spec.FarExpiration.IsZero() {
+		spec.FarExpiration = spec.Expiration.AddDate(0, 0, 30)
+	}
+	if spec.AsOf.IsZero() {
+		spec.AsOf = time.Now()
+	}
+
+	center := math.Round(spec.Spot/spec.Width) * spec.Width
+	legs := make([]models.Leg, 0, len(layout))
+	for _, tl := range layout {
+		leg := models.Leg{
+			OptionType:     tl.optionType,
+			Side:           tl.side,
+			Quantity:       tl.quantity,
+			ExpirationDate: spec.Expiration,
+		}
+		if tl.far {
+			leg.ExpirationDate = spec.FarExpiration
+		}
+
+		if tl.optionType == models.OptionTypeStock {
+			leg.Premium = spec.Spot
+			legs = append(legs, leg)
+			continue
+		}
+
+		leg.Strike = center + tl.offset*spec.Width
+		if leg.Strike <= 0 {
+			return nil, fmt.Errorf("strike width %.2f is too wide for spot %.2f", spec.Width, spec.Spot)
+		}
+		premium, err := pricing.Price(pricing.Inputs{
+			OptionType:   tl.optionType,
+			Spot:         spec.Spot,
+			Strike:       leg.Strike,
+			TimeToExpiry: pricing.YearsToExpiry(spec.AsOf, models.ExpirationCutoff(leg.ExpirationDate)),
+			Rate:         spec.Rate,
+			Volatility:   spec.Volatility,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to price %s leg: %w", strategy, err)
+		}
+		leg.Premium = math.Round(premium*100) / 100
+		legs = append(legs, leg)
+	}
+
+	return legs, nil
+}
@@ -0,0 +1,428 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// US Letter page size and margins, in points
+const (
+	PageWidth  = 612.0
+	PageHeight = 792.0
+	Margin     = 48.0
+)
+
+// Font is one of the standard PDF fonts, which every reader provides
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+// Color is an RGB color with components from 0 to 1
+type Color struct {
+	R, G, B float64
+}
+
+// Common colors
+var (
+	Black     = Color{0, 0, 0}
+	Gray      = Color{0.45, 0.45, 0.45}
+	LightGray = Color{0.88, 0.88, 0.88}
+	Red       = Color{0.78, 0.16, 0.16}
+	Green     = Color{0.13, 0.55, 0.13}
+)
+
+// Align is the horizontal alignment of a table column
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+)
+
+// Column describes a table column: its title, width in points and alignment
+type Column struct {
+	Title string
+	Width float64
+	Align Align
+}
+
+// Document is a PDF being laid out top to bottom. Positions passed to its
+// drawing methods are measured from the top-left corner of the page, and
+// the flow methods (Heading, Paragraph, Table) advance a cursor, starting a
+// new page when the current one is full.
+type Document struct {
+	title   string
+	created time.Time
+	pages   []*bytes.Buffer
+	page    *bytes.Buffer
+	y       float64
+}
+
+// New creates a document with a single empty page
+func New(title string) *Document {
+	d := &Document{title: title, created: time.Now()}
+	d.AddPage()
+	return d
+}
+
+// AddPage starts a new page and moves the cursor to its top margin
+func (d *Document) AddPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = Margin
+}
+
+// Pages returns the number of pages
+func (d *Document) Pages() int {
+	return len(d.pages)
+}
+
+// Y returns the cursor's distance from the top of the page
+func (d *Document) Y() float64 {
+	return d.y
+}
+
+// Space moves the cursor down by h points
+func (d *Document) Space(h float64) {
+	d.y += h
+}
+
+// EnsureSpace starts a new page unless h points remain above the bottom
+// margin
+func (d *Document) EnsureSpace(h float64) {
+	if d.y+h > PageHeight-Margin {
+		d.AddPage()
+	}
+}
+
+// Text draws s with its baseline at (x, y)
+func (d *Document) Text(x, y float64, font Font, size float64, color Color, s string) {
+	fmt.Fprintf(d.page, "BT %s rg /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		colorOps(color), font+1, num(size), num(x), num(PageHeight-y), encode(s))
+}
+
+// Rect fills a rectangle whose top-left corner is (x, y)
+func (d *Document) Rect(x, y, w, h float64, color Color) {
+	fmt.Fprintf(d.page, "%s rg %s %s %s %s re f\n",
+		colorOps(color), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// Line strokes a line from (x1, y1) to (x2, y2)
+func (d *Document) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(d.page, "%s RG %s w %s %s m %s %s l S\n",
+		colorOps(color), num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Circle fills a circle centred on (x, y), drawn as four Bézier arcs
+func (d *Document) Circle(x, y, r float64, color Color) {
+	const k = 0.5523 // control point distance for a quarter circle
+	cy := PageHeight - y
+	fmt.Fprintf(d.page, "%s rg %s %s m ", colorOps(color), num(x+r), num(cy))
+	fmt.Fprintf(d.page, "%s %s %s %s %s %s c ", num(x+r), num(cy+k*r), num(x+k*r), num(cy+r), num(x), num(cy+r))
+	fmt.Fprintf(d.page, "%s %s %s %s %s %s c ", num(x-k*r), num(cy+r), num(x-r), num(cy+k*r), num(x-r), num(cy))
+	fmt.Fprintf(d.page, "%s %s %s %s %s %s c ", num(x-r), num(cy-k*r), num(x-k*r), num(cy-r), num(x), num(cy-r))
+	fmt.Fprintf(d.page, "%s %s %s %s %s %s c f\n", num(x+k*r), num(cy-r), num(x+r), num(cy-k*r), num(x+r), num(cy))
+}
+
+// Heading writes a bold line of text at the cursor with a rule beneath it
+func (d *Document) Heading(s string) {
+	d.EnsureSpace(40)
+	d.y += 16
+	d.Text(Margin, d.y, HelveticaBold, 13, Black, s)
+	d.y += 5
+	d.Line(Margin, d.y, PageWidth-Margin, d.y, 0.75, Gray)
+	d.y += 12
+}
+
+// Paragraph writes text at the cursor, wrapped to the page width
+func (d *Document) Paragraph(font Font, size float64, color Color, s string) {
+	lineHeight := size * 1.35
+	for _, line := range Wrap(font, size, PageWidth-2*Margin, s) {
+		d.EnsureSpace(lineHeight)
+		d.y += size
+		d.Text(Margin, d.y, font, size, color, line)
+		d.y += lineHeight - size
+	}
+}
+
+// Table writes rows under a header row at the cursor, repeating the header
+// at the top of each new page. Cells too wide for their column are cut
+// short with an ellipsis. colors, when non-nil, gives each cell's text
+// color.
+func (d *Document) Table(columns []Column, rows [][]string, colors func(row, col int) Color) {
+	const size, rowHeight = 9.0, 15.0
+
+	header := func() {
+		d.Rect(Margin, d.y, PageWidth-2*Margin, rowHeight, LightGray)
+		d.tableRow(columns, HelveticaBold, size, rowHeight, func(col int) (string, Color) {
+			return columns[col].Title, Black
+		})
+	}
+
+	d.EnsureSpace(2 * rowHeight)
+	header()
+	for r, row := range rows {
+		if d.y+rowHeight > PageHeight-Margin {
+			d.AddPage()
+			header()
+		}
+		d.tableRow(columns, Helvetica, size, rowHeight, func(col int) (string, Color) {
+			text := ""
+			if col < len(row) {
+				text = row[col]
+			}
+			if colors != nil {
+				return text, colors(r, col)
+			}
+			return text, Black
+		})
+	}
+}
+
+func (d *Document) tableRow(columns []Column, font Font, size, height float64, cell func(col int) (string, Color)) {
+	const padding = 4.0
+	x := Margin
+	baseline := d.y + height - (height-size)/2 - 2
+	for i, col := range columns {
+		text, color := cell(i)
+		if text = Truncate(font, size, col.Width-2*padding, text); text != "" {
+			tx := x + padding
+			if col.Align == AlignRight {
+				tx = x + col.Width - padding - TextWidth(font, size, text)
+			}
+			d.Text(tx, baseline, font, size, color, text)
+		}
+		x += col.Width
+	}
+	d.y += height
+	d.Line(Margin, d.y, PageWidth-Margin, d.y, 0.25, LightGray)
+}
+
+// AddFooters writes text and the page number at the foot of every page. Call
+// it once the layout is complete.
+func (d *Document) AddFooters(text string) {
+	current := d.page
+	y := PageHeight - Margin/2
+	for i, page := range d.pages {
+		d.page = page
+		d.Text(Margin, y, Helvetica, 8, Gray, text)
+		label := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		d.Text(PageWidth-Margin-TextWidth(Helvetica, 8, label), y, Helvetica, 8, Gray, label)
+	}
+	d.page = current
+}
+
+// Write encodes the document as a PDF file
+func (d *Document) Write(w io.Writer) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; each page then takes a page object followed by
+	// its content stream
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Trading Dashboard) /CreationDate (D:%s) >>",
+		encode(d.title), d.created.UTC().Format("20060102150405Z")))
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), firstPage+2*i+1))
+
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return fmt.Errorf("failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to compress page: %w", err)
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if _, err := w.Write(out.Bytes()); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+// TextWidth returns the width of s in points
+func TextWidth(font Font, size float64, s string) float64 {
+	widths := helveticaWidths
+	if font == HelveticaBold {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for _, b := range []byte(encodeBytes(s)) {
+		if b >= 32 && b <= 126 {
+			total += widths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Truncate shortens s with an ellipsis until it fits in width points
+func Truncate(font Font, size, width float64, s string) string {
+	if TextWidth(font, size, s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if t := strings.TrimSpace(string(runes)) + "..."; TextWidth(font, size, t) <= width {
+			return t
+		}
+	}
+	return ""
+}
+
+// Wrap breaks s into lines no wider than width points, at spaces where
+// possible
+func Wrap(font Font, size, width float64, s string) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && TextWidth(font, size, candidate) > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			for TextWidth(font, size, candidate) > width && len([]rune(candidate)) > 1 {
+				// A single word wider than the line is split wherever it overflows
+				runes := []rune(candidate)
+				n := len(runes) - 1
+				for n > 1 && TextWidth(font, size, string(runes[:n])) > width {
+					n--
+				}
+				lines = append(lines, string(runes[:n]))
+				candidate = string(runes[n:])
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func colorOps(c Color) string {
+	return num(c.R) + " " + num(c.G) + " " + num(c.B)
+}
+
+// num formats a number for a content stream, which does not accept
+// exponents
+func num(v float64) string {
+	if math.Abs(v) < 0.0005 {
+		return "0"
+	}
+	s := fmt.Sprintf("%.3f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// encodeBytes converts s to WinAnsi bytes. Latin-1 characters map to
+// themselves, a few common typographic marks to their WinAnsi codes, and
+// anything else to a question mark.
+func encodeBytes(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteByte(' ')
+		case r >= 32 && r <= 126, r >= 160 && r <= 255:
+			b.WriteByte(byte(r))
+		case r == '–':
+			b.WriteByte(0x96)
+		case r == '—':
+			b.WriteByte(0x97)
+		case r == '‘':
+			b.WriteByte(0x91)
+		case r == '’':
+			b.WriteByte(0x92)
+		case r == '“':
+			b.WriteByte(0x93)
+		case r == '”':
+			b.WriteByte(0x94)
+		case r == '•':
+			b.WriteByte(0x95)
+		case r == '…':
+			b.WriteByte(0x85)
+		case r == '€':
+			b.WriteByte(0x80)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// encode converts s to a PDF literal string body
+func encode(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(encodeBytes(s)) {
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c >= 128 {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Advance widths of the printable ASCII characters, from the Adobe font
+// metrics, in thousandths of the font size
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0 to ?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @ to O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P to _
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // ` to o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p to ~
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611, // 0 to ?
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778, // @ to O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556, // P to _
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611, // ` to o
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584, // p to ~
+}
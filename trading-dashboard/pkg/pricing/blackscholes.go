@@ -0,0 +1,148 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Option types understood by the pricing engine
+const (
+	Call = "call"
+	Put  = "put"
+)
+
+// Inputs holds the parameters of a European option valuation. TimeToExpiry
+// is in years; Rate, Volatility and DividendYield are annualized decimals
+// (0.05 = 5%).
+type Inputs struct {
+	OptionType    string  `json:"option_type"`
+	Spot          float64 `json:"spot"`
+	Strike        float64 `json:"strike"`
+	TimeToExpiry  float64 `json:"time_to_expiry"`
+	Rate          float64 `json:"rate"`
+	Volatility    float64 `json:"volatility"`
+	DividendYield float64 `json:"dividend_yield"`
+}
+
+// Greeks holds an option price and its sensitivities. Theta is per calendar
+// day, Vega per one volatility point and Rho per one percentage point of rate.
+type Greeks struct {
+	Price float64 `json:"price"`
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Theta float64 `json:"theta"`
+	Vega  float64 `json:"vega"`
+	Rho   float64 `json:"rho"`
+}
+
+const daysPerYear = 365.0
+
+// YearsToExpiry converts the time between asOf and expiration to years,
+// floored at zero
+func YearsToExpiry(asOf, expiration time.Time) float64 {
+	years := expiration.Sub(asOf).Hours() / 24 / daysPerYear
+	if years < 0 {
+		return 0
+	}
+	return years
+}
+
+// Validate checks that the inputs describe a valuable option
+func (in Inputs) Validate() error {
+	if in.OptionType != Call && in.OptionType != Put {
+		return fmt.Errorf("invalid option type: %s", in.OptionType)
+	}
+	if in.Spot <= 0 {
+		return fmt.Errorf("spot price must be greater than zero")
+	}
+	if in.Strike <= 0 {
+		return fmt.Errorf("strike must be greater than zero")
+	}
+	if in.TimeToExpiry < 0 {
+		return fmt.Errorf("time to expiry cannot be negative")
+	}
+	if in.Volatility < 0 {
+		return fmt.Errorf("volatility cannot be negative")
+	}
+	return nil
+}
+
+// Price returns the Black-Scholes value of a European option
+func Price(in Inputs) (float64, error) {
+	g, err := Calculate(in)
+	if err != nil {
+		return 0, err
+	}
+	return g.Price, nil
+}
+
+// Calculate returns the Black-Scholes value and Greeks of a European option.
+// At expiry, or with zero volatility, the option is valued at its
+// (discounted) intrinsic value.
+func Calculate(in Inputs) (Greeks, error) {
+	if err := in.Validate(); err != nil {
+		return Greeks{}, err
+	}
+
+	S, K, T, r, q, v := in.Spot, in.Strike, in.TimeToExpiry, in.Rate, in.DividendYield, in.Volatility
+	isCall := in.OptionType == Call
+
+	if T == 0 || v == 0 {
+		return intrinsicGreeks(in), nil
+	}
+
+	sqrtT := math.Sqrt(T)
+	d1 := (math.Log(S/K) + (r-q+0.5*v*v)*T) / (v * sqrtT)
+	d2 := d1 - v*sqrtT
+	dfR := math.Exp(-r * T)
+	dfQ := math.Exp(-q * T)
+	pdf := normPDF(d1)
+
+	var g Greeks
+	g.Gamma = dfQ * pdf / (S * v * sqrtT)
+	g.Vega = S * dfQ * pdf * sqrtT / 100
+
+	commonTheta := -S * dfQ * pdf * v / (2 * sqrtT)
+	if isCall {
+		g.Price = S*dfQ*normCDF(d1) - K*dfR*normCDF(d2)
+		g.Delta = dfQ * normCDF(d1)
+		g.Theta = (commonTheta - r*K*dfR*normCDF(d2) + q*S*dfQ*normCDF(d1)) / daysPerYear
+		g.Rho = K * T * dfR * normCDF(d2) / 100
+	} else {
+		g.Price = K*dfR*normCDF(-d2) - S*dfQ*normCDF(-d1)
+		g.Delta = -dfQ * normCDF(-d1)
+		g.Theta = (commonTheta + r*K*dfR*normCDF(-d2) - q*S*dfQ*normCDF(-d1)) / daysPerYear
+		g.Rho = -K * T * dfR * normCDF(-d2) / 100
+	}
+
+	return g, nil
+}
+
+// intrinsicGreeks values an option with no time value remaining
+func intrinsicGreeks(in Inputs) Greeks {
+	forward := in.Spot * math.Exp((in.Rate-in.DividendYield)*in.TimeToExpiry)
+	df := math.Exp(-in.Rate * in.TimeToExpiry)
+
+	var g Greeks
+	if in.OptionType == Call {
+		if forward > in.Strike {
+			g.Price = df * (forward - in.Strike)
+			g.Delta = math.Exp(-in.DividendYield * in.TimeToExpiry)
+		}
+	} else if in.Strike > forward {
+		g.Price = df * (in.Strike - forward)
+		g.Delta = -math.Exp(-in.DividendYield * in.TimeToExpiry)
+	}
+	return g
+}
+
+// normCDF is the standard normal cumulative distribution function
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// normPDF is the standard normal probability density function
+func normPDF(x float64) float64 {
+	return math.Exp(-0.5*x*x) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,95 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	minVolatility = 1e-4
+	maxVolatility = 5.0
+	ivTolerance   = 1e-6
+	ivMaxIter     = 100
+)
+
+// ImpliedVolatility solves for the volatility at which the Black-Scholes price
+// of the option equals premium. The Volatility field of in is used as the
+// starting guess when set. Newton-Raphson is tried first, falling back to
+// bisection when vega is too small to make progress.
+func ImpliedVolatility(in Inputs, premium float64) (float64, error) {
+	vol := 0.3
+	if in.Volatility > minVolatility && in.Volatility < maxVolatility {
+		vol = in.Volatility
+	}
+	if err := in.Validate(); err != nil {
+		return 0, err
+	}
+	if in.TimeToExpiry == 0 {
+		return 0, fmt.Errorf("cannot solve implied volatility at expiration")
+	}
+
+	lower, err := priceAt(in, minVolatility)
+	if err != nil {
+		return 0, err
+	}
+	upper, err := priceAt(in, maxVolatility)
+	if err != nil {
+		return 0, err
+	}
+	if premium < lower-ivTolerance {
+		return 0, fmt.Errorf("premium %.4f is below the option's minimum value %.4f", premium, lower)
+	}
+	if premium > upper+ivTolerance {
+		return 0, fmt.Errorf("premium %.4f is above the option's maximum value %.4f", premium, upper)
+	}
+
+	for i := 0; i < ivMaxIter; i++ {
+		in.Volatility = vol
+		g, err := Calculate(in)
+		if err != nil {
+			return 0, err
+		}
+		diff := g.Price - premium
+		if math.Abs(diff) < ivTolerance {
+			return vol, nil
+		}
+		vegaPerUnit := g.Vega * 100
+		if vegaPerUnit < 1e-8 {
+			break
+		}
+		next := vol - diff/vegaPerUnit
+		if next <= minVolatility || next >= maxVolatility || math.IsNaN(next) {
+			break
+		}
+		vol = next
+	}
+
+	return bisectVolatility(in, premium)
+}
+
+// bisectVolatility brackets the implied volatility between the solver bounds
+func bisectVolatility(in Inputs, premium float64) (float64, error) {
+	lo, hi := minVolatility, maxVolatility
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		price, err := priceAt(in, mid)
+		if err != nil {
+			return 0, err
+		}
+		if math.Abs(price-premium) < ivTolerance || hi-lo < 1e-10 {
+			return mid, nil
+		}
+		if price > premium {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return (lo + hi) / 2, nil
+}
+
+// priceAt prices the option at the given volatility
+func priceAt(in Inputs, vol float64) (float64, error) {
+	in.Volatility = vol
+	return Price(in)
+}
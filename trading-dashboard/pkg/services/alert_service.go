@@ -0,0 +1,512 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// AlertService checks open trades and market ratings against the alert rules
+// and keeps the alert inbox
+type AlertService struct {
+	db       *sql.DB
+	trades   *TradeService
+	market   *MarketService
+	settings *SettingsService
+}
+
+// NewAlertService creates a new alert service
+func NewAlertService(db *sql.DB) *AlertService {
+	return &AlertService{
+		db:       db,
+		trades:   NewTradeService(db),
+		market:   NewMarketService(db),
+		settings: NewSettingsService(db),
+	}
+}
+
+// GetAlertRules returns the saved alert rules, with defaults for any rule
+// that was never saved
+func (s *AlertService) GetAlertRules() (models.AlertRules, error) {
+	rules := models.DefaultAlertRules()
+	stored, ok, err := s.settings.GetSetting(models.SettingAlertRules)
+	if err != nil || !ok {
+		return rules, err
+	}
+	if err := json.Unmarshal([]byte(stored), &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SaveAlertRules validates and stores the alert rules
+func (s *AlertService) SaveAlertRules(rules models.AlertRules) error {
+	if err := rules.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert rules: %w", err)
+	}
+	return s.settings.SetSetting(models.SettingAlertRules, string(data))
+}
+
+// GetPrices returns every stored underlying price ordered by ticker
+func (s *AlertService) GetPrices() ([]models.UnderlyingPrice, error) {
+	rows, err := s.db.Query("SELECT ticker, price, updated_at FROM underlying_prices ORDER BY ticker")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query underlying prices: %w", err)
+	}
+	defer rows.Close()
+
+	prices := []models.UnderlyingPrice{}
+	for rows.Next() {
+		var p models.UnderlyingPrice
+		if err := rows.Scan(&p.Ticker, &p.Price, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan underlying price: %w", err)
+		}
+		prices = append(prices, p)
+	}
+	return prices, rows.Err()
+}
+
+// SetPrice stores the latest price of an underlying, replacing any previous
+// value
+func (s *AlertService) SetPrice(ticker string, price float64) error {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return fmt.Errorf("%w: ticker is required", ErrValidation)
+	}
+	if math.IsNaN(price) || math.IsInf(price, 0) || price <= 0 {
+		return fmt.Errorf("%w: price must be greater than 0", ErrValidation)
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO underlying_prices (ticker, price) VALUES (?, ?)
+		ON CONFLICT(ticker) DO UPDATE SET price = excluded.price, updated_at = CURRENT_TIMESTAMP
+	`, ticker, price)
+	if err != nil {
+		return fmt.Errorf("failed to save price for %s: %w", ticker, err)
+	}
+	return nil
+}
+
+// DeletePrice removes an underlying's price so its targets and stops are no
+// longer checked
+func (s *AlertService) DeletePrice(ticker string) error {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if _, err := s.db.Exec("DELETE FROM underlying_prices WHERE ticker = ?", ticker); err != nil {
+		return fmt.Errorf("failed to delete price for %s: %w", ticker, err)
+	}
+	return nil
+}
+
+// GetAlerts returns the inbox newest first. limit caps the number of alerts
+// returned when positive.
+func (s *AlertService) GetAlerts(unreadOnly bool, limit int) ([]models.Alert, error) {
+	query := `SELECT ` + alertColumns + ` FROM alerts`
+	if unreadOnly {
+		query += " WHERE read_at IS NULL"
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	args := []any{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.Alert{}
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		alerts = append(alerts, *alert)
+	}
+	return alerts, rows.Err()
+}
+
+// alertColumns lists the alert columns read by scanAlert
+const alertColumns = `id, rule, trade_id, ticker, sector, message, created_at, read_at`
+
+// scanAlert scans a row selected with alertColumns
+func scanAlert(row interface{ Scan(...any) error }) (*models.Alert, error) {
+	var alert models.Alert
+	err := row.Scan(&alert.ID, &alert.Rule, &alert.TradeID, &alert.Ticker, &alert.Sector,
+		&alert.Message, &alert.CreatedAt, &alert.ReadAt)
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// archivedAlerts returns every alert with its dedupe key, oldest first
+func (s *AlertService) archivedAlerts() ([]models.ArchivedAlert, error) {
+	rows, err := s.db.Query(`SELECT ` + alertColumns + `, dedupe_key FROM alerts ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.ArchivedAlert{}
+	for rows.Next() {
+		var key string
+		alert, err := scanAlert(extraColumns{row: rows, extra: []any{&key}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		alerts = append(alerts, models.ArchivedAlert{Alert: *alert, DedupeKey: key})
+	}
+	return alerts, rows.Err()
+}
+
+// remapAlertKey replaces the trade or rating ID that follows the rule in a
+// dedupe key, for alerts whose records were given new IDs
+func remapAlertKey(key string, id int64) string {
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) < 2 {
+		return key
+	}
+	parts[1] = strconv.FormatInt(id, 10)
+	return strings.Join(parts, ":")
+}
+
+// GetUnreadCount returns the number of unread alerts
+func (s *AlertService) GetUnreadCount() (int, error) {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM alerts WHERE read_at IS NULL").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread alerts: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks one alert as read
+func (s *AlertService) MarkRead(id int64) error {
+	result, err := s.db.Exec("UPDATE alerts SET read_at = COALESCE(read_at, ?) WHERE id = ?", time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark alert read: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("alert %w", ErrNotFound)
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread alert as read and returns how many it marked
+func (s *AlertService) MarkAllRead() (int64, error) {
+	result, err := s.db.Exec("UPDATE alerts SET read_at = ? WHERE read_at IS NULL", time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark alerts read: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// CheckAlerts evaluates every enabled rule and adds each new match to the
+// inbox. A condition alerts once: the same target, stop, expiration or
+// rating change is never reported twice, read or not. It returns the alerts
+// added by this check.
+func (s *AlertService) CheckAlerts(now time.Time) ([]models.Alert, error) {
+	rules, err := s.GetAlertRules()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []alertCandidate{}
+	if rules.TargetStop.Enabled || rules.Expiring.Enabled {
+		open, err := s.trades.GetOpenTrades()
+		if err != nil {
+			return nil, err
+		}
+		if rules.TargetStop.Enabled {
+			prices, err := s.GetPrices()
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, targetStopAlerts(open, prices)...)
+		}
+		if rules.Expiring.Enabled {
+			candidates = append(candidates, expiringAlerts(open, rules.Expiring.Days, now)...)
+		}
+	}
+	if rules.RatingSign.Enabled {
+		ratingAlerts, err := s.ratingSignAlerts()
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, ratingAlerts...)
+	}
+
+	return s.insertAlerts(candidates, now)
+}
+
+// RunAlertScheduler checks the alert rules immediately and then on every
+// interval until ctx is cancelled. notify is called with each non-empty
+// batch of new alerts.
+func (s *AlertService) RunAlertScheduler(ctx context.Context, interval time.Duration, notify func([]models.Alert)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		alerts, err := s.CheckAlerts(time.Now())
+		if err != nil {
+			log.Printf("Alert check failed: %v", err)
+		} else if len(alerts) > 0 {
+			log.Printf("Alert check raised %d alert(s)", len(alerts))
+			if notify != nil {
+				notify(alerts)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// alertCandidate is a matched condition not yet in the inbox
+type alertCandidate struct {
+	alert models.Alert
+	key   string
+}
+
+// insertAlerts adds the candidates whose dedupe key is not in the inbox yet
+func (s *AlertService) insertAlerts(candidates []alertCandidate, now time.Time) ([]models.Alert, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	created := []models.Alert{}
+	for _, c := range candidates {
+		alert := c.alert
+		alert.CreatedAt = now.UTC()
+		// NOT EXISTS rather than ON CONFLICT, which would still use up an ID
+		result, err := tx.Exec(`
+			INSERT INTO alerts (rule, trade_id, ticker, sector, message, dedupe_key, created_at)
+			SELECT ?, ?, ?, ?, ?, ?, ?
+			WHERE NOT EXISTS (SELECT 1 FROM alerts WHERE dedupe_key = ?)
+		`, alert.Rule, alert.TradeID, alert.Ticker, alert.Sector, alert.Message, c.key, alert.CreatedAt, c.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert alert: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		if alert.ID, err = result.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("failed to get alert ID: %w", err)
+		}
+		created = append(created, alert)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+// targetStopAlerts matches open trades whose underlying has reached the
+// trade's target price or stop loss. When a trade has both levels their
+// order tells which side each is on; with only one, the strategy's direction
+// does, and trades of unknown or neutral direction are skipped.
+func targetStopAlerts(open []models.OptionsTrade, prices []models.UnderlyingPrice) []alertCandidate {
+	latest := make(map[string]float64, len(prices))
+	for _, p := range prices {
+		latest[p.Ticker] = p.Price
+	}
+
+	candidates := []alertCandidate{}
+	for _, trade := range open {
+		price, ok := latest[strings.ToUpper(trade.Ticker)]
+		if !ok || (trade.TargetPrice == nil && trade.StopLoss == nil) {
+			continue
+		}
+
+		var targetAbove bool
+		switch {
+		case trade.TargetPrice != nil && trade.StopLoss != nil:
+			if *trade.TargetPrice == *trade.StopLoss {
+				continue
+			}
+			targetAbove = *trade.TargetPrice > *trade.StopLoss
+		case models.StrategyDirection(trade.StrategyType) == models.DirectionBullish:
+			targetAbove = true
+		case models.StrategyDirection(trade.StrategyType) == models.DirectionBearish:
+			targetAbove = false
+		default:
+			continue
+		}
+
+		id := trade.ID
+		if target := trade.TargetPrice; target != nil && reached(price, *target, targetAbove) {
+			candidates = append(candidates, alertCandidate{
+				alert: models.Alert{
+					Rule:    models.AlertTargetHit,
+					TradeID: &id,
+					Ticker:  trade.Ticker,
+					Sector:  trade.Sector,
+					Message: fmt.Sprintf("%s at %.2f reached the target of %.2f on %s #%d",
+						trade.Ticker, price, *target, trade.StrategyType, trade.ID),
+				},
+				key: fmt.Sprintf("%s:%d:%g", models.AlertTargetHit, trade.ID, *target),
+			})
+		}
+		if stop := trade.StopLoss; stop != nil && reached(price, *stop, !targetAbove) {
+			candidates = append(candidates, alertCandidate{
+				alert: models.Alert{
+					Rule:    models.AlertStopHit,
+					TradeID: &id,
+					Ticker:  trade.Ticker,
+					Sector:  trade.Sector,
+					Message: fmt.Sprintf("%s at %.2f hit the stop of %.2f on %s #%d",
+						trade.Ticker, price, *stop, trade.StrategyType, trade.ID),
+				},
+				key: fmt.Sprintf("%s:%d:%g", models.AlertStopHit, trade.ID, *stop),
+			})
+		}
+	}
+	return candidates
+}
+
+// reached reports whether price is at or beyond level on the given side
+func reached(price, level float64, above bool) bool {
+	if above {
+		return price >= level
+	}
+	return price <= level
+}
+
+// expiringAlerts matches open trades expiring within the given number of
+// calendar days
+func expiringAlerts(open []models.OptionsTrade, days int, now time.Time) []alertCandidate {
+	candidates := []alertCandidate{}
+	for _, trade := range open {
+		dte := daysBetween(now, trade.ExpirationDate)
+		if dte < 0 || dte > days {
+			continue
+		}
+		id := trade.ID
+		expiration := trade.ExpirationDate.Format("2006-01-02")
+		candidates = append(candidates, alertCandidate{
+			alert: models.Alert{
+				Rule:    models.AlertExpiring,
+				TradeID: &id,
+				Ticker:  trade.Ticker,
+				Sector:  trade.Sector,
+				Message: fmt.Sprintf("%s %s #%d expires %s (%d day(s) left)",
+					trade.Ticker, trade.StrategyType, trade.ID, expiration, dte),
+			},
+			key: fmt.Sprintf("%s:%d:%s", models.AlertExpiring, trade.ID, expiration),
+		})
+	}
+	return candidates
+}
+
+// ratingSignAlerts compares the two latest rating snapshots and matches every
+// sector whose rating moved between positive, zero and negative
+func (s *AlertService) ratingSignAlerts() ([]alertCandidate, error) {
+	rows, err := s.db.Query("SELECT id FROM market_ratings ORDER BY created_at DESC, id DESC LIMIT 2")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market ratings: %w", err)
+	}
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan market rating: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query market ratings: %w", err)
+	}
+	if len(ids) < 2 {
+		return []alertCandidate{}, nil
+	}
+
+	latest, err := s.market.GetRatingByID(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.market.GetRatingByID(ids[1])
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []alertCandidate{}
+	for _, sector := range orderLabels(sectorNames(latest.SectorRatings), s.market.GetSectorNames()) {
+		current := latest.SectorRatings[sector]
+		before, ok := previous.SectorRatings[sector]
+		if !ok || ratingSign(current) == ratingSign(before) {
+			continue
+		}
+		open, err := s.trades.queryAllTrades(models.TradeQuery{
+			Sectors:  []string{sector},
+			Statuses: []string{models.StatusActive, models.StatusAdjusted},
+		})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, alertCandidate{
+			alert: models.Alert{
+				Rule:   models.AlertRatingSign,
+				Sector: sector,
+				Message: fmt.Sprintf("%s rating turned %s (%+g to %+g); %d open trade(s) in the sector",
+					sector, signName(current), before, current, len(open)),
+			},
+			key: fmt.Sprintf("%s:%d:%s", models.AlertRatingSign, latest.ID, sector),
+		})
+	}
+	return candidates, nil
+}
+
+func sectorNames(ratings map[string]float64) []string {
+	names := make([]string, 0, len(ratings))
+	for name := range ratings {
+		names = append(names, name)
+	}
+	return names
+}
+
+func ratingSign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func signName(v float64) string {
+	switch ratingSign(v) {
+	case 1:
+		return "positive"
+	case -1:
+		return "negative"
+	default:
+		return "neutral"
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+type AnalyticsService struct {
+	trades *TradeService
+	market *MarketService
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(db *sql.DB) *AnalyticsService {
+	return &AnalyticsService{
+		trades: NewTradeService(db),
+		market: NewMarketService(db),
+	}
+}
+
+// GetSentimentEdgeReport joins every finished trade entered within the date
+// range to the rating snapshot in effect at the end of its entry day, then
+// reports win rate and realized P&L by sector rating bucket and by strategy
+// category. Active trades are excluded because they have no outcome yet.
+func (s *AnalyticsService) GetSentimentEdgeReport(startDate, endDate time.Time) (*models.SentimentEdgeReport, error) {
+	trades, err := s.trades.GetTrades(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.strategyCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SentimentEdgeReport{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Trades:    []models.RatedTrade{},
+	}
+
+	snapshots := map[string]*models.MarketRating{}
+	for _, trade := range trades {
+		if !models.IsFinishedStatus(trade.Status) {
+			continue
+		}
+
+		rated, err := s.rateTrade(trade, categories, snapshots)
+		if err != nil {
+			return nil, err
+		}
+		report.Trades = append(report.Trades, rated)
+	}
+
+	report.BySectorRating = groupOutcomes(report.Trades, func(t models.RatedTrade) string { return t.RatingBucket },
+		[]string{models.RatingBucketBearish, models.RatingBucketNeutral, models.RatingBucketBullish, models.RatingBucketUnrated})
+	report.ByCategory = groupOutcomes(report.Trades, func(t models.RatedTrade) string { return t.Category }, nil)
+	report.Overall = summarizeOutcomes("All trades", report.Trades)
+
+	return report, nil
+}
+
+// rateTrade joins a trade to its entry-day rating snapshot and realized P&L.
+// Snapshots are cached by entry day since many trades share one.
+func (s *AnalyticsService) rateTrade(trade models.OptionsTrade, categories map[string]string, snapshots map[string]*models.MarketRating) (models.RatedTrade, error) {
+	rated := models.RatedTrade{
+		TradeID:      trade.ID,
+		Ticker:       trade.Ticker,
+		Sector:       trade.Sector,
+		StrategyType: trade.StrategyType,
+		Category:     categories[trade.StrategyType],
+		EntryDate:    trade.EntryDate,
+	}
+	if rated.Category == "" {
+		rated.Category = "Other"
+	}
+
+	day := trade.EntryDate.Format("2006-01-02")
+	snapshot, ok := snapshots[day]
+	if !ok {
+		y, m, d := trade.EntryDate.Date()
+		endOfDay := time.Date(y, m, d, 23, 59, 59, 0, time.UTC)
+		var err error
+		snapshot, err = s.market.GetRatingAsOf(endOfDay)
+		if err != nil {
+			return rated, err
+		}
+		snapshots[day] = snapshot
+	}
+
+	if snapshot != nil {
+		rated.MarketRatingID = &snapshot.ID
+		rated.OverallRating = &snapshot.OverallRating
+		if v, ok := snapshot.SectorRatings[trade.Sector]; ok {
+			rated.SectorRating = &v
+		}
+	}
+	rated.RatingBucket = models.RatingBucket(rated.SectorRating)
+
+	fills, err := s.trades.GetFills(trade.ID)
+	if err != nil {
+		return rated, err
+	}
+	rated.RealizedPnL = calculatePnL(trade, fills, nil, nil).RealizedPnL
+	switch {
+	case rated.RealizedPnL > 0:
+		rated.Outcome = models.OutcomeWin
+	case rated.RealizedPnL < 0:
+		rated.Outcome = models.OutcomeLoss
+	default:
+		rated.Outcome = models.OutcomeFlat
+	}
+
+	return rated, nil
+}
+
+// strategyCategories maps strategy names to their category
+func (s *AnalyticsService) strategyCategories() (map[string]string, error) {
+	strategies, err := s.trades.GetStrategyTypes()
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[string]string, len(strategies))
+	for _, st := range strategies {
+		categories[st.Name] = st.Category
+	}
+	return categories, nil
+}
+
+// groupOutcomes buckets trades by key. Buckets listed in order come first
+// (even when empty); any others follow alphabetically.
+func groupOutcomes(trades []models.RatedTrade, key func(models.RatedTrade) string, order []string) []models.OutcomeBucket {
+	groups := map[string][]models.RatedTrade{}
+	for _, t := range trades {
+		groups[key(t)] = append(groups[key(t)], t)
+	}
+
+	labels := append([]string{}, order...)
+	listed := map[string]bool{}
+	for _, l := range order {
+		listed[l] = true
+	}
+	var extra []string
+	for l := range groups {
+		if !listed[l] {
+			extra = append(extra, l)
+		}
+	}
+	sort.Strings(extra)
+	labels = append(labels, extra...)
+
+	buckets := make([]models.OutcomeBucket, 0, len(labels))
+	for _, l := range labels {
+		buckets = append(buckets, summarizeOutcomes(l, groups[l]))
+	}
+	return buckets
+}
+
+// summarizeOutcomes computes win rate and P&L for a group of trades
+func summarizeOutcomes(label string, trades []models.RatedTrade) models.OutcomeBucket {
+	bucket := models.OutcomeBucket{Label: label, Trades: len(trades)}
+	for _, t := range trades {
+		bucket.RealizedPnL += t.RealizedPnL
+		switch t.Outcome {
+		case models.OutcomeWin:
+			bucket.Wins++
+		case models.OutcomeLoss:
+			bucket.Losses++
+		}
+	}
+	if bucket.Trades > 0 {
+		bucket.WinRate = float64(bucket.Wins) / float64(bucket.Trades)
+		bucket.AveragePnL = bucket.RealizedPnL / float64(bucket.Trades)
+	}
+	return bucket
+}
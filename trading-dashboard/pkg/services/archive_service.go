@@ -0,0 +1,708 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// ArchiveService exports the whole workspace to a portable JSON archive and
+// imports archives back, merging into or replacing the existing data
+type ArchiveService struct {
+	db       *sql.DB
+	trades   *TradeService
+	market   *MarketService
+	settings *SettingsService
+	betas    *BetaService
+	alerts   *AlertService
+}
+
+// NewArchiveService creates a new archive service
+func NewArchiveService(db *sql.DB) *ArchiveService {
+	return &ArchiveService{
+		db:       db,
+		trades:   NewTradeService(db),
+		market:   NewMarketService(db),
+		settings: NewSettingsService(db),
+		betas:    NewBetaService(db),
+		alerts:   NewAlertService(db),
+	}
+}
+
+// archiveEnvelope reads an archive without decoding its data, so the
+// checksum is computed over the bytes as written
+type archiveEnvelope struct {
+	Format     string          `json:"format"`
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Checksum   string          `json:"checksum"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// ExportArchive writes every market rating, strategy type, trade, setting,
+// beta, underlying price and alert to w as an indented JSON archive
+func (s *ArchiveService) ExportArchive(w io.Writer) (*models.ArchiveSummary, error) {
+	data, err := s.collect()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+
+	var schemaVersion int
+	if err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&schemaVersion); err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	archive := models.Archive{
+		Format:        models.ArchiveFormat,
+		Version:       models.ArchiveVersion,
+		SchemaVersion: schemaVersion,
+		ExportedAt:    time.Now().UTC(),
+		Checksum:      archiveChecksum(payload),
+		Data:          *data,
+	}
+
+	out, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if _, err := w.Write(append(out, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return &models.ArchiveSummary{
+		ExportedAt: archive.ExportedAt,
+		Checksum:   archive.Checksum,
+		Counts: models.ArchiveCounts{
+			MarketRatings:    len(data.MarketRatings),
+			StrategyTypes:    len(data.StrategyTypes),
+			Trades:           len(data.Trades),
+			Settings:         len(data.Settings),
+			Betas:            len(data.Betas),
+			UnderlyingPrices: len(data.UnderlyingPrices),
+			Alerts:           len(data.Alerts),
+		},
+	}, nil
+}
+
+// collect reads every record that goes into an archive
+func (s *ArchiveService) collect() (*models.ArchiveData, error) {
+	data := &models.ArchiveData{
+		MarketRatings: []models.MarketRating{},
+		StrategyTypes: []models.StrategyType{},
+		Trades:        []models.ArchivedTrade{},
+	}
+
+	ratingIDs, err := s.ids("SELECT id FROM market_ratings ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market ratings: %w", err)
+	}
+	for _, id := range ratingIDs {
+		rating, err := s.market.GetRatingByID(id)
+		if err != nil {
+			return nil, err
+		}
+		data.MarketRatings = append(data.MarketRatings, *rating)
+	}
+
+	strategies, err := s.trades.GetStrategyTypes()
+	if err != nil {
+		return nil, err
+	}
+	data.StrategyTypes = append(data.StrategyTypes, strategies...)
+
+	rows, err := s.db.Query(`SELECT ` + tradeColumns + ` FROM options_trades ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
+	trades := []models.OptionsTrade{}
+	for rows.Next() {
+		trade, err := scanTrade(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, *trade)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	trades, err = s.trades.attachLegs(trades)
+	if err != nil {
+		return nil, err
+	}
+	for _, trade := range trades {
+		fills, err := s.trades.GetFills(trade.ID)
+		if err != nil {
+			return nil, err
+		}
+		history, err := s.trades.GetStatusHistory(trade.ID)
+		if err != nil {
+			return nil, err
+		}
+		data.Trades = append(data.Trades, models.ArchivedTrade{
+			OptionsTrade:  trade,
+			Fills:         fills,
+			StatusHistory: history,
+		})
+	}
+
+	data.Settings, err = s.settings.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	data.Betas, err = s.betas.GetBetas()
+	if err != nil {
+		return nil, err
+	}
+	data.UnderlyingPrices, err = s.alerts.GetPrices()
+	if err != nil {
+		return nil, err
+	}
+	data.Alerts, err = s.alerts.archivedAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// ids runs a query returning a single ID column
+func (s *ArchiveService) ids(query string) ([]int64, error) {
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ImportArchive loads an archive in a single transaction. In merge mode new
+// IDs are assigned and roll links, fills, history and alerts are remapped to
+// them; records already present are skipped, so importing an archive twice
+// adds nothing. Replace mode deletes the existing ratings, trades, strategy
+// types, settings, betas, underlying prices and alerts and keeps the
+// archive's IDs.
+func (s *ArchiveService) ImportArchive(r io.Reader, mode string) (*models.ArchiveImportResult, error) {
+	if mode != models.ArchiveModeMerge && mode != models.ArchiveModeReplace {
+		return nil, fmt.Errorf("%w: invalid import mode: %s", ErrValidation, mode)
+	}
+
+	envelope, data, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	imp := &archiveImport{
+		tx:        tx,
+		replace:   mode == models.ArchiveModeReplace,
+		tradeIDs:  map[int64]int64{},
+		ratingIDs: map[int64]int64{},
+		result:    &models.ArchiveImportResult{Mode: mode, ExportedAt: envelope.ExportedAt},
+	}
+
+	if imp.replace {
+		if err := imp.clear(); err != nil {
+			return nil, err
+		}
+	}
+	if err := imp.strategyTypes(data.StrategyTypes); err != nil {
+		return nil, err
+	}
+	if err := imp.settings(data.Settings); err != nil {
+		return nil, err
+	}
+	if err := imp.betas(data.Betas); err != nil {
+		return nil, err
+	}
+	if err := imp.underlyingPrices(data.UnderlyingPrices); err != nil {
+		return nil, err
+	}
+	if err := imp.marketRatings(data.MarketRatings); err != nil {
+		return nil, err
+	}
+	if err := imp.trades(data.Trades); err != nil {
+		return nil, err
+	}
+	if err := imp.alerts(data.Alerts); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return imp.result, nil
+}
+
+// readArchive decodes an archive and verifies its format, version and
+// checksum
+func readArchive(r io.Reader) (*archiveEnvelope, *models.ArchiveData, error) {
+	var envelope archiveEnvelope
+	if err := json.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, nil, fmt.Errorf("%w: not a valid archive: %w", ErrValidation, err)
+	}
+	if envelope.Format != models.ArchiveFormat {
+		return nil, nil, fmt.Errorf("%w: not a Trading Dashboard archive", ErrValidation)
+	}
+	if envelope.Version < 1 || envelope.Version > models.ArchiveVersion {
+		return nil, nil, fmt.Errorf("%w: archive version %d is not supported (latest is %d)", ErrValidation, envelope.Version, models.ArchiveVersion)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, envelope.Data); err != nil {
+		return nil, nil, fmt.Errorf("%w: archive data is not valid JSON: %w", ErrValidation, err)
+	}
+	if archiveChecksum(compact.Bytes()) != envelope.Checksum {
+		return nil, nil, fmt.Errorf("%w: archive checksum does not match; the file is damaged or was edited", ErrValidation)
+	}
+
+	var data models.ArchiveData
+	if err := json.Unmarshal(compact.Bytes(), &data); err != nil {
+		return nil, nil, fmt.Errorf("%w: archive data is invalid: %w", ErrValidation, err)
+	}
+	return &envelope, &data, nil
+}
+
+// archiveChecksum returns the checksum recorded for compact archive data
+func archiveChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// sqliteTimestamp formats a time the way CURRENT_TIMESTAMP stores it, so
+// imported timestamps compare like native ones
+func sqliteTimestamp(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// archiveImport carries the state of one archive import
+type archiveImport struct {
+	tx      *sql.Tx
+	replace bool
+	// tradeIDs maps archive trade IDs to IDs in this database
+	tradeIDs map[int64]int64
+	// ratingIDs maps archive market rating IDs to IDs in this database
+	ratingIDs map[int64]int64
+	// lastTradeID is the highest trade ID before the import
+	lastTradeID int64
+	result      *models.ArchiveImportResult
+}
+
+// clear deletes everything a replace import overwrites
+func (imp *archiveImport) clear() error {
+	tables := []string{
+		"alerts",
+		"trade_status_history",
+		"trade_fills",
+		"trade_legs",
+		"options_trades",
+		"sector_ratings",
+		"market_ratings",
+		"strategy_types",
+		"settings",
+		"betas",
+		"underlying_prices",
+	}
+	for _, table := range tables {
+		if _, err := imp.tx.Exec("DELETE FROM " + table); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// exists reports whether a query finds at least one row
+func (imp *archiveImport) exists(query string, args ...any) (bool, error) {
+	var count int
+	if err := imp.tx.QueryRow(query, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// strategyTypes adds strategy types whose name is not defined yet
+func (imp *archiveImport) strategyTypes(strategies []models.StrategyType) error {
+	for _, strategy := range strategies {
+		found, err := imp.exists("SELECT COUNT(*) FROM strategy_types WHERE name = ?", strategy.Name)
+		if err != nil {
+			return fmt.Errorf("failed to check strategy type %s: %w", strategy.Name, err)
+		}
+		if found {
+			imp.result.Skipped.StrategyTypes++
+			continue
+		}
+		_, err = imp.tx.Exec(`
+			INSERT INTO strategy_types (name, category, description, color_hex)
+			VALUES (?, ?, ?, ?)
+		`, strategy.Name, strategy.Category, strategy.Description, strategy.ColorHex)
+		if err != nil {
+			return fmt.Errorf("failed to import strategy type %s: %w", strategy.Name, err)
+		}
+		imp.result.Imported.StrategyTypes++
+	}
+	return nil
+}
+
+// settings adds the archive's settings; when merging, local values win
+func (imp *archiveImport) settings(settings map[string]string) error {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		result, err := imp.tx.Exec("INSERT OR IGNORE INTO settings (key, value) VALUES (?, ?)", key, settings[key])
+		if err != nil {
+			return fmt.Errorf("failed to import setting %s: %w", key, err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			imp.result.Skipped.Settings++
+		} else {
+			imp.result.Imported.Settings++
+		}
+	}
+	return nil
+}
+
+// betas adds the archive's betas; when merging, local values win
+func (imp *archiveImport) betas(betas []models.Beta) error {
+	for _, beta := range betas {
+		result, err := imp.tx.Exec("INSERT OR IGNORE INTO betas (ticker, beta, updated_at) VALUES (?, ?, ?)",
+			beta.Ticker, beta.Beta, sqliteTimestamp(beta.UpdatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to import beta for %s: %w", beta.Ticker, err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			imp.result.Skipped.Betas++
+		} else {
+			imp.result.Imported.Betas++
+		}
+	}
+	return nil
+}
+
+// underlyingPrices adds the archive's underlying prices; when merging, local
+// values win
+func (imp *archiveImport) underlyingPrices(prices []models.UnderlyingPrice) error {
+	for _, price := range prices {
+		result, err := imp.tx.Exec("INSERT OR IGNORE INTO underlying_prices (ticker, price, updated_at) VALUES (?, ?, ?)",
+			price.Ticker, price.Price, sqliteTimestamp(price.UpdatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to import price for %s: %w", price.Ticker, err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			imp.result.Skipped.UnderlyingPrices++
+		} else {
+			imp.result.Imported.UnderlyingPrices++
+		}
+	}
+	return nil
+}
+
+// alerts adds the archive's alerts after the trades and ratings they refer
+// to, moving their trade IDs and dedupe keys onto the imported records.
+// Alerts already in the inbox, by dedupe key, are skipped, as are alerts
+// whose trade or rating is not in the archive.
+func (imp *archiveImport) alerts(alerts []models.ArchivedAlert) error {
+	for _, alert := range alerts {
+		key := alert.DedupeKey
+		var tradeID *int64
+		if alert.TradeID != nil {
+			id, ok := imp.tradeIDs[*alert.TradeID]
+			if !ok {
+				imp.result.Skipped.Alerts++
+				continue
+			}
+			tradeID = &id
+			key = remapAlertKey(key, id)
+		} else if alert.Rule == models.AlertRatingSign {
+			parts := strings.SplitN(key, ":", 3)
+			if len(parts) < 3 {
+				return fmt.Errorf("failed to import alert %d: invalid dedupe key %q", alert.ID, key)
+			}
+			ratingID, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to import alert %d: invalid dedupe key %q", alert.ID, key)
+			}
+			id, ok := imp.ratingIDs[ratingID]
+			if !ok {
+				imp.result.Skipped.Alerts++
+				continue
+			}
+			key = remapAlertKey(key, id)
+		}
+
+		columns := "rule, trade_id, ticker, sector, message, dedupe_key, created_at, read_at"
+		values := "?, ?, ?, ?, ?, ?, ?, ?"
+		args := []any{alert.Rule, tradeID, alert.Ticker, alert.Sector, alert.Message, key, alert.CreatedAt, alert.ReadAt}
+		if imp.replace {
+			columns, values = "id, "+columns, "?, "+values
+			args = append([]any{alert.ID}, args...)
+		}
+		result, err := imp.tx.Exec("INSERT INTO alerts ("+columns+") SELECT "+values+
+			" WHERE NOT EXISTS (SELECT 1 FROM alerts WHERE dedupe_key = ?)", append(args, key)...)
+		if err != nil {
+			return fmt.Errorf("failed to import alert %d: %w", alert.ID, err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			imp.result.Skipped.Alerts++
+		} else {
+			imp.result.Imported.Alerts++
+		}
+	}
+	return nil
+}
+
+// marketRatings adds rating snapshots with their sector ratings, skipping
+// snapshots taken at the same moment with the same overall rating
+func (imp *archiveImport) marketRatings(ratings []models.MarketRating) error {
+	for _, rating := range ratings {
+		createdAt := sqliteTimestamp(rating.CreatedAt)
+		if !imp.replace {
+			var existingID int64
+			err := imp.tx.QueryRow(`
+				SELECT id FROM market_ratings
+				WHERE julianday(created_at) = julianday(?) AND overall_rating = ?
+				ORDER BY id LIMIT 1
+			`, createdAt, rating.OverallRating).Scan(&existingID)
+			if err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("failed to check market rating: %w", err)
+			}
+			if existingID != 0 {
+				imp.ratingIDs[rating.ID] = existingID
+				imp.result.Skipped.MarketRatings++
+				continue
+			}
+		}
+
+		columns := "overall_rating, created_at, updated_at"
+		values := "?, ?, ?"
+		args := []any{rating.OverallRating, createdAt, sqliteTimestamp(rating.UpdatedAt)}
+		if imp.replace {
+			columns, values = "id, "+columns, "?, "+values
+			args = append([]any{rating.ID}, args...)
+		}
+		result, err := imp.tx.Exec("INSERT INTO market_ratings ("+columns+") VALUES ("+values+")", args...)
+		if err != nil {
+			return fmt.Errorf("failed to import market rating %d: %w", rating.ID, err)
+		}
+		ratingID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get market rating ID: %w", err)
+		}
+		imp.ratingIDs[rating.ID] = ratingID
+
+		sectors := make([]string, 0, len(rating.SectorRatings))
+		for sector := range rating.SectorRatings {
+			sectors = append(sectors, sector)
+		}
+		sort.Strings(sectors)
+		for _, sector := range sectors {
+			_, err := imp.tx.Exec(`
+				INSERT INTO sector_ratings (market_rating_id, sector_name, rating, created_at)
+				VALUES (?, ?, ?, ?)
+			`, ratingID, sector, rating.SectorRatings[sector], createdAt)
+			if err != nil {
+				return fmt.Errorf("failed to import sector rating for %s: %w", sector, err)
+			}
+		}
+		imp.result.Imported.MarketRatings++
+	}
+	return nil
+}
+
+// trades adds trades in ID order, so a rolled trade's parent is normally
+// imported before it, then links any roll whose parent came later
+func (imp *archiveImport) trades(trades []models.ArchivedTrade) error {
+	sort.Slice(trades, func(i, j int) bool { return trades[i].ID < trades[j].ID })
+
+	// Only trades that were here before the import count as duplicates, so
+	// identical trades within the archive are all imported
+	if err := imp.tx.QueryRow("SELECT COALESCE(MAX(id), 0) FROM options_trades").Scan(&imp.lastTradeID); err != nil {
+		return fmt.Errorf("failed to query trades: %w", err)
+	}
+
+	// Trades whose parent had not been imported yet, by new ID
+	pendingParents := map[int64]int64{}
+
+	for _, trade := range trades {
+		if !imp.replace {
+			existingID, err := imp.existingTrade(trade)
+			if err != nil {
+				return err
+			}
+			if existingID != 0 {
+				imp.tradeIDs[trade.ID] = existingID
+				imp.result.Skipped.Trades++
+				continue
+			}
+		}
+
+		var parent any
+		if trade.ParentTradeID != nil {
+			if id, ok := imp.tradeIDs[*trade.ParentTradeID]; ok {
+				parent = id
+			} else if imp.replace {
+				parent = *trade.ParentTradeID
+			}
+		}
+
+		columns := `ticker, sector, strategy_type, entry_date, expiration_date, target_price, stop_loss,
+			status, notes, external_id, parent_trade_id, created_at, updated_at`
+		values := "?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?"
+		args := []any{
+			trade.Ticker, trade.Sector, trade.StrategyType, trade.EntryDate, trade.ExpirationDate,
+			trade.TargetPrice, trade.StopLoss, trade.Status, trade.Notes, nullString(trade.ExternalID),
+			parent, sqliteTimestamp(trade.CreatedAt), sqliteTimestamp(trade.UpdatedAt),
+		}
+		if imp.replace {
+			columns, values = "id, "+columns, "?, "+values
+			args = append([]any{trade.ID}, args...)
+		}
+		result, err := imp.tx.Exec("INSERT INTO options_trades ("+columns+") VALUES ("+values+")", args...)
+		if err != nil {
+			return fmt.Errorf("failed to import trade %d (%s): %w", trade.ID, trade.Ticker, err)
+		}
+		tradeID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get trade ID: %w", err)
+		}
+		imp.tradeIDs[trade.ID] = tradeID
+		if trade.ParentTradeID != nil && parent == nil {
+			pendingParents[tradeID] = *trade.ParentTradeID
+		}
+
+		if err := imp.tradeDetails(tradeID, trade); err != nil {
+			return err
+		}
+		imp.result.Imported.Trades++
+	}
+
+	for tradeID, archiveParentID := range pendingParents {
+		parentID, ok := imp.tradeIDs[archiveParentID]
+		if !ok {
+			// The parent is not in the archive; keep the trade unlinked
+			continue
+		}
+		if _, err := imp.tx.Exec("UPDATE options_trades SET parent_trade_id = ? WHERE id = ?", parentID, tradeID); err != nil {
+			return fmt.Errorf("failed to link rolled trade: %w", err)
+		}
+	}
+	return nil
+}
+
+// existingTrade returns the ID of a trade that was in the database before
+// the import and that an archived trade duplicates, or 0. Trades match on
+// their broker ID, or else on ticker, strategy, dates, notes, leg count and
+// creation time.
+func (imp *archiveImport) existingTrade(trade models.ArchivedTrade) (int64, error) {
+	query := `
+		SELECT id FROM options_trades
+		WHERE id <= ? AND ticker = ? AND strategy_type = ?
+		  AND julianday(entry_date) = julianday(?) AND julianday(expiration_date) = julianday(?)
+		  AND COALESCE(notes, '') = ? AND julianday(created_at) = julianday(?)
+		  AND (SELECT COUNT(*) FROM trade_legs WHERE trade_id = options_trades.id) = ?
+		ORDER BY id LIMIT 1
+	`
+	args := []any{
+		imp.lastTradeID, trade.Ticker, trade.StrategyType, trade.EntryDate, trade.ExpirationDate,
+		trade.Notes, sqliteTimestamp(trade.CreatedAt), len(trade.Legs),
+	}
+	if trade.ExternalID != "" {
+		query = "SELECT id FROM options_trades WHERE external_id = ?"
+		args = []any{trade.ExternalID}
+	}
+
+	var id int64
+	err := imp.tx.QueryRow(query, args...).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to check for existing trade: %w", err)
+	}
+	return id, nil
+}
+
+// tradeDetails adds the legs, fills and status history of an imported trade
+func (imp *archiveImport) tradeDetails(tradeID int64, trade models.ArchivedTrade) error {
+	for i, leg := range trade.Legs {
+		_, err := imp.tx.Exec(`
+			INSERT INTO trade_legs (
+				trade_id, option_type, side, strike, expiration_date, quantity, premium, created_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, tradeID, leg.OptionType, leg.Side, leg.Strike, leg.ExpirationDate, leg.Quantity, leg.Premium, sqliteTimestamp(leg.CreatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to import leg %d of trade %d: %w", i+1, trade.ID, err)
+		}
+	}
+
+	for _, fill := range trade.Fills {
+		externalID := nullString(fill.ExternalID)
+		if externalID.Valid {
+			// Broker IDs are unique; a clash means the fill is already
+			// recorded elsewhere, so keep this copy without it
+			found, err := imp.exists("SELECT COUNT(*) FROM trade_fills WHERE external_id = ?", fill.ExternalID)
+			if err != nil {
+				return fmt.Errorf("failed to check fill: %w", err)
+			}
+			if found {
+				externalID = sql.NullString{}
+			}
+		}
+		_, err := imp.tx.Exec(`
+			INSERT INTO trade_fills (
+				trade_id, action, side, price, quantity, fees, filled_at, external_id, created_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, tradeID, fill.Action, fill.Side, fill.Price, fill.Quantity, fill.Fees, fill.FilledAt, externalID, sqliteTimestamp(fill.CreatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to import fill of trade %d: %w", trade.ID, err)
+		}
+	}
+
+	for _, change := range trade.StatusHistory {
+		_, err := imp.tx.Exec(`
+			INSERT INTO trade_status_history (trade_id, from_status, to_status, reason, changed_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, tradeID, change.FromStatus, change.ToStatus, change.Reason, change.ChangedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import status history of trade %d: %w", trade.ID, err)
+		}
+	}
+	return nil
+}
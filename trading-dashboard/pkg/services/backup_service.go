@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"trading-dashboard/pkg/database"
+	"trading-dashboard/pkg/models"
+)
+
+// Backup files are named trading_dashboard-<UTC stamp>-<reason>.db so they
+// sort by age and describe themselves without a catalog
+const (
+	backupPrefix      = "trading_dashboard-"
+	backupSuffix      = ".db"
+	backupStampLayout = "20060102-150405.000"
+)
+
+// BackupService snapshots the database into a directory and keeps a rotated
+// set of the most recent backups
+type BackupService struct {
+	db   *database.DB
+	dir  string
+	keep int
+	mu   sync.Mutex
+}
+
+// NewBackupService creates a backup service writing to dir and keeping the
+// newest keep backups
+func NewBackupService(db *database.DB, dir string, keep int) *BackupService {
+	if keep <= 0 {
+		keep = models.DefaultBackupRetention
+	}
+	return &BackupService{db: db, dir: dir, keep: keep}
+}
+
+// CreateBackup takes a consistent snapshot of the database and deletes the
+// backups beyond the retention count
+func (s *BackupService) CreateBackup(reason string) (*models.BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.createBackup(reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.pruneBackups(); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// createBackup writes a snapshot without rotating; the caller holds s.mu
+func (s *BackupService) createBackup(reason string) (*models.BackupInfo, error) {
+	// Two backups in the same millisecond get consecutive stamps
+	stamp := time.Now().UTC().Truncate(time.Millisecond)
+	var path string
+	for {
+		path = filepath.Join(s.dir, backupPrefix+stamp.Format(backupStampLayout)+"-"+reason+backupSuffix)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		stamp = stamp.Add(time.Millisecond)
+	}
+
+	if err := s.db.BackupTo(path); err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	info, _ := parseBackupName(filepath.Base(path))
+	info.Path = path
+	info.Size = stat.Size()
+	return &info, nil
+}
+
+// ListBackups returns the backups in the backup directory, newest first
+func (s *BackupService) ListBackups() ([]models.BackupInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []models.BackupInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := []models.BackupInfo{}
+	for _, entry := range entries {
+		info, ok := parseBackupName(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+		stat, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		info.Path = filepath.Join(s.dir, entry.Name())
+		info.Size = stat.Size()
+		backups = append(backups, info)
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name > backups[j].Name })
+	return backups, nil
+}
+
+// RestoreBackup replaces the database file at dbPath with the named backup.
+// The backup is validated first and the current database is saved as a
+// pre-restore backup. On success the service's database has been closed and
+// the caller must reopen dbPath; the pre-restore backup is returned so a
+// failed reopen can be rolled back.
+func (s *BackupService) RestoreBackup(name, dbPath string) (*models.BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.backupPath(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := database.CheckDatabaseFile(path); err != nil {
+		return nil, fmt.Errorf("%w: backup %s cannot be restored: %w", ErrValidation, name, err)
+	}
+
+	// Rotating here could delete the backup being restored
+	safety, err := s.createBackup(models.BackupReasonPreRestore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save current database before restore: %w", err)
+	}
+
+	if err := s.db.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close database: %w", err)
+	}
+	return safety, database.ReplaceDatabaseFile(dbPath, path)
+}
+
+// RunBackupScheduler takes a backup every interval until ctx is cancelled,
+// starting with a startup backup when startup is set
+func (s *BackupService) RunBackupScheduler(ctx context.Context, interval time.Duration, startup bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	reason := ""
+	if startup {
+		reason = models.BackupReasonStartup
+	}
+
+	for {
+		if reason != "" {
+			if info, err := s.CreateBackup(reason); err != nil {
+				log.Printf("Database backup failed: %v", err)
+			} else {
+				log.Printf("Backed up database to %s", info.Path)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reason = models.BackupReasonScheduled
+		}
+	}
+}
+
+// backupPath resolves a backup name to a file in the backup directory,
+// rejecting anything that is not one of its backups
+func (s *BackupService) backupPath(name string) (string, error) {
+	if _, ok := parseBackupName(name); !ok || filepath.Base(name) != name {
+		return "", fmt.Errorf("%w: invalid backup name %q", ErrValidation, name)
+	}
+	path := filepath.Join(s.dir, name)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", fmt.Errorf("backup %w", ErrNotFound)
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read backup: %w", err)
+	}
+	return path, nil
+}
+
+// pruneBackups deletes the oldest backups beyond the retention count
+func (s *BackupService) pruneBackups() error {
+	backups, err := s.ListBackups()
+	if err != nil {
+		return err
+	}
+	for i := s.keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete old backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseBackupName reads the timestamp and reason from a backup file name
+func parseBackupName(name string) (models.BackupInfo, bool) {
+	info := models.BackupInfo{Name: name}
+	if !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupSuffix) {
+		return info, false
+	}
+	rest := strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupSuffix)
+	if len(rest) < len(backupStampLayout)+2 || rest[len(backupStampLayout)] != '-' {
+		return info, false
+	}
+
+	createdAt, err := time.Parse(backupStampLayout, rest[:len(backupStampLayout)])
+	if err != nil {
+		return info, false
+	}
+	info.CreatedAt = createdAt
+	info.Reason = rest[len(backupStampLayout)+1:]
+	return info, true
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+)
+
+// BetaService stores each underlying's beta against the benchmark
+type BetaService struct {
+	db *sql.DB
+}
+
+// NewBetaService creates a new beta service
+func NewBetaService(db *sql.DB) *BetaService {
+	return &BetaService{db: db}
+}
+
+// GetBetas returns every stored beta ordered by ticker
+func (s *BetaService) GetBetas() ([]models.Beta, error) {
+	rows, err := s.db.Query("SELECT ticker, beta, updated_at FROM betas ORDER BY ticker")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query betas: %w", err)
+	}
+	defer rows.Close()
+
+	betas := []models.Beta{}
+	for rows.Next() {
+		var b models.Beta
+		if err := rows.Scan(&b.Ticker, &b.Beta, &b.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan beta: %w", err)
+		}
+		betas = append(betas, b)
+	}
+	return betas, rows.Err()
+}
+
+// SetBeta stores a ticker's beta, replacing any previous value
+func (s *BetaService) SetBeta(ticker string, beta float64) error {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return fmt.Errorf("%w: ticker is required", ErrValidation)
+	}
+	if math.IsNaN(beta) || math.IsInf(beta, 0) || math.Abs(beta) > 10 {
+		return fmt.Errorf("%w: beta must be between -10 and 10", ErrValidation)
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO betas (ticker, beta) VALUES (?, ?)
+		ON CONFLICT(ticker) DO UPDATE SET beta = excluded.beta, updated_at = CURRENT_TIMESTAMP
+	`, ticker, beta)
+	if err != nil {
+		return fmt.Errorf("failed to save beta for %s: %w", ticker, err)
+	}
+	return nil
+}
+
+// DeleteBeta removes a ticker's beta so it is weighted at 1.0 again
+func (s *BetaService) DeleteBeta(ticker string) error {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if _, err := s.db.Exec("DELETE FROM betas WHERE ticker = ?", ticker); err != nil {
+		return fmt.Errorf("failed to delete beta for %s: %w", ticker, err)
+	}
+	return nil
+}
+
+// betaMap returns the stored betas keyed by ticker
+func (s *BetaService) betaMap() (map[string]float64, error) {
+	betas, err := s.GetBetas()
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]float64, len(betas))
+	for _, b := range betas {
+		m[b.Ticker] = b.Beta
+	}
+	return m, nil
+}
@@ -0,0 +1,10 @@
+package services
+
+import "errors"
+
+// Sentinel errors wrapped by service methods so callers such as the REST API
+// can classify failures with errors.Is
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("validation failed")
+)
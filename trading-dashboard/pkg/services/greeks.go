@@ -0,0 +1,80 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/pricing"
+)
+
+// CalculatePositionGreeks values each leg of a trade with Black-Scholes at
+// asOf and sums the position-weighted Greeks. Long legs count positively and
+// short legs negatively; stock legs carry delta only.
+func CalculatePositionGreeks(trade models.OptionsTrade, req models.GreeksRequest, asOf time.Time) (*models.PositionGreeks, error) {
+	if len(trade.Legs) == 0 {
+		return nil, fmt.Errorf("trade %d has no legs to value", trade.ID)
+	}
+
+	result := &models.PositionGreeks{
+		TradeID: trade.ID,
+		AsOf:    asOf,
+		Legs:    make([]models.LegGreeks, 0, len(trade.Legs)),
+	}
+
+	for _, leg := range trade.Legs {
+		g, err := legGreeks(leg, req, asOf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to value leg %d: %w", leg.ID, err)
+		}
+		result.Legs = append(result.Legs, models.LegGreeks{LegID: leg.ID, Greeks: g})
+		result.Total = addGreeks(result.Total, g)
+	}
+
+	return result, nil
+}
+
+// legGreeks returns the Greeks of a leg scaled by quantity, multiplier and side
+func legGreeks(leg models.Leg, req models.GreeksRequest, asOf time.Time) (pricing.Greeks, error) {
+	scale := float64(leg.Quantity) * leg.Multiplier()
+	if leg.Side == models.SideSell {
+		scale = -scale
+	}
+
+	if leg.OptionType == models.OptionTypeStock {
+		return pricing.Greeks{Price: req.UnderlyingPrice * scale, Delta: scale}, nil
+	}
+
+	g, err := pricing.Calculate(pricing.Inputs{
+		OptionType:   leg.OptionType,
+		Spot:         req.UnderlyingPrice,
+		Strike:       leg.Strike,
+		TimeToExpiry: pricing.YearsToExpiry(asOf, models.ExpirationCutoff(leg.ExpirationDate)),
+		Rate:         req.Rate,
+		Volatility:   req.Volatility,
+	})
+	if err != nil {
+		return pricing.Greeks{}, err
+	}
+
+	return pricing.Greeks{
+		Price: g.Price * scale,
+		Delta: g.Delta * scale,
+		Gamma: g.Gamma * scale,
+		Theta: g.Theta * scale,
+		Vega:  g.Vega * scale,
+		Rho:   g.Rho * scale,
+	}, nil
+}
+
+// addGreeks sums two sets of Greeks
+func addGreeks(a, b pricing.Greeks) pricing.Greeks {
+	return pricing.Greeks{
+		Price: a.Price + b.Price,
+		Delta: a.Delta + b.Delta,
+		Gamma: a.Gamma + b.Gamma,
+		Theta: a.Theta + b.Theta,
+		Vega:  a.Vega + b.Vega,
+		Rho:   a.Rho + b.Rho,
+	}
+}
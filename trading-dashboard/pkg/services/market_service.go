@@ -3,6 +3,7 @@ package services
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"trading-dashboard/pkg/models"
@@ -21,13 +22,13 @@ func NewMarketService(db *sql.DB) *MarketService {
 func (s *MarketService) SaveRating(req models.MarketRatingRequest) (*models.MarketRating, error) {
 	// Validate overall rating
 	if !models.ValidateRating(req.OverallRating) {
-		return nil, fmt.Errorf("invalid overall rating: %f (must be between -3 and 3)", req.OverallRating)
+		return nil, fmt.Errorf("%w: invalid overall rating: %f (must be between -3 and 3)", ErrValidation, req.OverallRating)
 	}
 
 	// Validate sector ratings
 	for sector, rating := range req.SectorRatings {
 		if !models.ValidateRating(rating) {
-			return nil, fmt.Errorf("invalid rating for sector %s: %f (must be between -3 and 3)", sector, rating)
+			return nil, fmt.Errorf("%w: invalid rating for sector %s: %f (must be between -3 and 3)", ErrValidation, sector, rating)
 		}
 	}
 
@@ -105,6 +106,26 @@ func (s *MarketService) GetLatestRating() (*models.MarketRating, error) {
 	return &rating, nil
 }
 
+// GetRatingAsOf retrieves the market rating in effect at the given time, i.e.
+// the latest snapshot saved at or before it. It returns nil when no rating
+// had been saved yet.
+func (s *MarketService) GetRatingAsOf(at time.Time) (*models.MarketRating, error) {
+	var id int64
+	err := s.db.QueryRow(`
+		SELECT id FROM market_ratings
+		WHERE created_at <= ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, at.UTC()).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get rating as of %s: %w", at.Format(time.RFC3339), err)
+	}
+	return s.GetRatingByID(id)
+}
+
 // GetRatingByID retrieves a market rating by ID
 func (s *MarketService) GetRatingByID(id int64) (*models.MarketRating, error) {
 	row := s.db.QueryRow(`
@@ -116,6 +137,9 @@ func (s *MarketService) GetRatingByID(id int64) (*models.MarketRating, error) {
 	var rating models.MarketRating
 	err := row.Scan(&rating.ID, &rating.OverallRating, &rating.CreatedAt, &rating.UpdatedAt)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("market rating %w", ErrNotFound)
+		}
 		return nil, fmt.Errorf("failed to get rating by ID: %w", err)
 	}
 
@@ -133,12 +157,12 @@ func (s *MarketService) GetRatingByID(id int64) (*models.MarketRating, error) {
 func (s *MarketService) UpdateRating(id int64, req models.MarketRatingRequest) (*models.MarketRating, error) {
 	// Validate ratings
 	if !models.ValidateRating(req.OverallRating) {
-		return nil, fmt.Errorf("invalid overall rating: %f", req.OverallRating)
+		return nil, fmt.Errorf("%w: invalid overall rating: %f", ErrValidation, req.OverallRating)
 	}
 
 	for sector, rating := range req.SectorRatings {
 		if !models.ValidateRating(rating) {
-			return nil, fmt.Errorf("invalid rating for sector %s: %f", sector, rating)
+			return nil, fmt.Errorf("%w: invalid rating for sector %s: %f", ErrValidation, sector, rating)
 		}
 	}
 
@@ -181,6 +205,120 @@ func (s *MarketService) UpdateRating(id int64, req models.MarketRatingRequest) (
 	return s.GetRatingByID(id)
 }
 
+// GetRatingsBetween retrieves every market rating snapshot saved within the
+// date range, oldest first
+func (s *MarketService) GetRatingsBetween(startDate, endDate time.Time) ([]models.MarketRating, error) {
+	rows, err := s.db.Query(`
+		SELECT id, overall_rating, created_at, updated_at
+		FROM market_ratings
+		WHERE created_at >= ? AND created_at <= ?
+		ORDER BY created_at, id
+	`, startDate.UTC(), endDate.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rating history: %w", err)
+	}
+
+	ratings := []models.MarketRating{}
+	for rows.Next() {
+		var rating models.MarketRating
+		if err := rows.Scan(&rating.ID, &rating.OverallRating, &rating.CreatedAt, &rating.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		ratings = append(ratings, rating)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range ratings {
+		sectorRatings, err := s.getSectorRatings(ratings[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sector ratings: %w", err)
+		}
+		ratings[i].SectorRatings = sectorRatings
+	}
+
+	return ratings, nil
+}
+
+// GetSectorRatingHistory retrieves a sector's rating from every snapshot
+// saved within the date range, oldest first
+func (s *MarketService) GetSectorRatingHistory(sector string, startDate, endDate time.Time) ([]models.SectorRatingPoint, error) {
+	rows, err := s.db.Query(`
+		SELECT sr.market_rating_id, sr.sector_name, sr.rating, mr.created_at
+		FROM sector_ratings sr
+		JOIN market_ratings mr ON mr.id = sr.market_rating_id
+		WHERE sr.sector_name = ? AND mr.created_at >= ? AND mr.created_at <= ?
+		ORDER BY mr.created_at, mr.id
+	`, sector, startDate.UTC(), endDate.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sector history: %w", err)
+	}
+	defer rows.Close()
+
+	points := []models.SectorRatingPoint{}
+	for rows.Next() {
+		var p models.SectorRatingPoint
+		if err := rows.Scan(&p.MarketRatingID, &p.SectorName, &p.Rating, &p.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sector rating: %w", err)
+		}
+		points = append(points, p)
+	}
+
+	return points, rows.Err()
+}
+
+// CompareRatings reports the change in the overall and sector ratings
+// between two snapshots
+func (s *MarketService) CompareRatings(fromID, toID int64) (*models.RatingComparison, error) {
+	from, err := s.GetRatingByID(fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetRatingByID(toID)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &models.RatingComparison{
+		From:          *from,
+		To:            *to,
+		OverallChange: to.OverallRating - from.OverallRating,
+		SectorChanges: []models.SectorRatingChange{},
+	}
+
+	sectors := map[string]bool{}
+	for name := range from.SectorRatings {
+		sectors[name] = true
+	}
+	for name := range to.SectorRatings {
+		sectors[name] = true
+	}
+	names := make([]string, 0, len(sectors))
+	for name := range sectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		change := models.SectorRatingChange{SectorName: name}
+		if v, ok := from.SectorRatings[name]; ok {
+			change.From = &v
+		}
+		if v, ok := to.SectorRatings[name]; ok {
+			change.To = &v
+		}
+		if change.From != nil && change.To != nil {
+			change.Change = *change.To - *change.From
+		}
+		comparison.SectorChanges = append(comparison.SectorChanges, change)
+	}
+
+	return comparison, nil
+}
+
 // getSectorRatings retrieves sector ratings for a market rating
 func (s *MarketService) getSectorRatings(marketRatingID int64) (map[string]float64, error) {
 	rows, err := s.db.Query(`
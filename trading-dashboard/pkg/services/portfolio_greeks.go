@@ -0,0 +1,232 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/pricing"
+)
+
+// PortfolioService measures the combined risk of the open trades
+type PortfolioService struct {
+	trades *TradeService
+	market *MarketService
+	betas  *BetaService
+}
+
+// NewPortfolioService creates a new portfolio service
+func NewPortfolioService(db *sql.DB) *PortfolioService {
+	return &PortfolioService{
+		trades: NewTradeService(db),
+		market: NewMarketService(db),
+		betas:  NewBetaService(db),
+	}
+}
+
+// greeksGroups accumulates buckets by label, counting each trade once per
+// bucket
+type greeksGroups struct {
+	buckets map[string]*models.GreeksBucket
+	seen    map[string]map[int64]bool
+}
+
+func newGreeksGroups() *greeksGroups {
+	return &greeksGroups{buckets: map[string]*models.GreeksBucket{}, seen: map[string]map[int64]bool{}}
+}
+
+func (g *greeksGroups) add(label string, tradeID int64, greeks pricing.Greeks, betaWeighted float64) {
+	bucket, ok := g.buckets[label]
+	if !ok {
+		bucket = &models.GreeksBucket{Label: label}
+		g.buckets[label] = bucket
+		g.seen[label] = map[int64]bool{}
+	}
+	if !g.seen[label][tradeID] {
+		g.seen[label][tradeID] = true
+		bucket.Trades++
+	}
+	addToBucket(bucket, greeks, betaWeighted)
+}
+
+// sorted returns the buckets in orderLabels order
+func (g *greeksGroups) sorted(order []string) []models.GreeksBucket {
+	labels := make([]string, 0, len(g.buckets))
+	for label := range g.buckets {
+		labels = append(labels, label)
+	}
+
+	buckets := []models.GreeksBucket{}
+	for _, label := range orderLabels(labels, order) {
+		buckets = append(buckets, *g.buckets[label])
+	}
+	return buckets
+}
+
+// orderLabels returns labels with those listed in order first, in that
+// order, then the rest alphabetically
+func orderLabels(labels []string, order []string) []string {
+	present := map[string]bool{}
+	for _, label := range labels {
+		present[label] = true
+	}
+
+	ordered := make([]string, 0, len(labels))
+	for _, label := range order {
+		if present[label] {
+			ordered = append(ordered, label)
+			delete(present, label)
+		}
+	}
+	var extra []string
+	for label := range present {
+		extra = append(extra, label)
+	}
+	sort.Strings(extra)
+	return append(ordered, extra...)
+}
+
+// normalizeQuotes keys quotes by upper-case ticker
+func normalizeQuotes(quotes map[string]models.Quote) map[string]models.Quote {
+	normalized := make(map[string]models.Quote, len(quotes))
+	for ticker, quote := range quotes {
+		normalized[strings.ToUpper(strings.TrimSpace(ticker))] = quote
+	}
+	return normalized
+}
+
+// openTrades returns the active and adjusted trades
+func (s *PortfolioService) openTrades() ([]models.OptionsTrade, error) {
+	return s.trades.queryAllTrades(models.TradeQuery{
+		Statuses: []string{models.StatusActive, models.StatusAdjusted},
+	})
+}
+
+func addToBucket(bucket *models.GreeksBucket, greeks pricing.Greeks, betaWeighted float64) {
+	bucket.Delta += greeks.Delta
+	bucket.Gamma += greeks.Gamma
+	bucket.Theta += greeks.Theta
+	bucket.Vega += greeks.Vega
+	bucket.BetaWeightedDelta += betaWeighted
+}
+
+// GetPortfolioGreeks values every leg of the open trades at asOf and sums the
+// Greeks in total, by sector, by strategy category and by the week each leg
+// expires. Delta is beta-weighted to SPY as delta x beta x price / SPY price,
+// and each sector's weighted delta is compared with its latest rating.
+func (s *PortfolioService) GetPortfolioGreeks(req models.PortfolioGreeksRequest, asOf time.Time) (*models.PortfolioGreeks, error) {
+	quotes := normalizeQuotes(req.Quotes)
+
+	benchmarkPrice := req.BenchmarkPrice
+	if benchmarkPrice == 0 {
+		benchmarkPrice = quotes[models.BenchmarkTicker].Price
+	}
+	if benchmarkPrice <= 0 {
+		return nil, fmt.Errorf("%w: a %s price is required to beta-weight delta", ErrValidation, models.BenchmarkTicker)
+	}
+
+	trades, err := s.openTrades()
+	if err != nil {
+		return nil, err
+	}
+	betas, err := s.betas.betaMap()
+	if err != nil {
+		return nil, err
+	}
+	strategies, err := s.trades.GetStrategyTypes()
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[string]string, len(strategies))
+	for _, st := range strategies {
+		categories[st.Name] = st.Category
+	}
+	rating, err := s.market.GetLatestRating()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.PortfolioGreeks{
+		AsOf:           asOf,
+		Benchmark:      models.BenchmarkTicker,
+		BenchmarkPrice: benchmarkPrice,
+		Total:          models.GreeksBucket{Label: "Portfolio"},
+		Unpriced:       []string{},
+		DefaultBeta:    []string{},
+	}
+	sectors, byCategory, weeks := newGreeksGroups(), newGreeksGroups(), newGreeksGroups()
+	unpriced, defaultBeta := map[string]bool{}, map[string]bool{}
+
+	for _, trade := range trades {
+		ticker := strings.ToUpper(trade.Ticker)
+		quote, ok := quotes[ticker]
+		if !ok || quote.Price <= 0 || quote.Volatility < 0 {
+			unpriced[ticker] = true
+			continue
+		}
+		beta, ok := betas[ticker]
+		if !ok {
+			beta = 1
+			if ticker != models.BenchmarkTicker {
+				defaultBeta[ticker] = true
+			}
+		}
+
+		category := categories[trade.StrategyType]
+		if category == "" {
+			category = "Other"
+		}
+		inputs := models.GreeksRequest{UnderlyingPrice: quote.Price, Volatility: quote.Volatility, Rate: req.Rate}
+
+		var tradeGreeks pricing.Greeks
+		for _, leg := range trade.Legs {
+			g, err := legGreeks(leg, inputs, asOf)
+			if err != nil {
+				return nil, fmt.Errorf("failed to value %s leg %d: %w", trade.Ticker, leg.ID, err)
+			}
+			tradeGreeks = addGreeks(tradeGreeks, g)
+
+			week, _ := models.ReportWeek(leg.ExpirationDate)
+			weeks.add(week.Format("2006-01-02"), trade.ID, g, g.Delta*beta*quote.Price/benchmarkPrice)
+		}
+
+		betaWeighted := tradeGreeks.Delta * beta * quote.Price / benchmarkPrice
+		sectors.add(trade.Sector, trade.ID, tradeGreeks, betaWeighted)
+		byCategory.add(category, trade.ID, tradeGreeks, betaWeighted)
+		result.Total.Trades++
+		addToBucket(&result.Total, tradeGreeks, betaWeighted)
+	}
+
+	result.BySector = sectors.sorted(models.GetSectorNames())
+	result.ByCategory = byCategory.sorted(nil)
+	result.ByExpiration = weeks.sorted(nil)
+
+	for i := range result.BySector {
+		bucket := &result.BySector[i]
+		if rating != nil {
+			if v, ok := rating.SectorRatings[bucket.Label]; ok {
+				bucket.Rating = &v
+			}
+		}
+		bucket.Bias = models.BiasOf(bucket.Rating, bucket.BetaWeightedDelta)
+	}
+	if rating != nil {
+		overall := rating.OverallRating
+		result.Total.Rating = &overall
+	}
+	result.Total.Bias = models.BiasOf(result.Total.Rating, result.Total.BetaWeightedDelta)
+
+	for ticker := range unpriced {
+		result.Unpriced = append(result.Unpriced, ticker)
+	}
+	for ticker := range defaultBeta {
+		result.DefaultBeta = append(result.DefaultBeta, ticker)
+	}
+	sort.Strings(result.Unpriced)
+	sort.Strings(result.DefaultBeta)
+
+	return result, nil
+}
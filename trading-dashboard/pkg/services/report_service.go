@@ -0,0 +1,433 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/pdf"
+)
+
+// ReportService builds the weekly basket review and renders it as a PDF
+type ReportService struct {
+	trades *TradeService
+	market *MarketService
+}
+
+// NewReportService creates a new report service
+func NewReportService(db *sql.DB) *ReportService {
+	return &ReportService{
+		trades: NewTradeService(db),
+		market: NewMarketService(db),
+	}
+}
+
+// GetBasketReport gathers the basket review for a date range: the rating
+// snapshot in effect at its end, open trades whose life overlaps it grouped
+// by sector, open trades expiring in the seven days after it and the P&L
+// realized within it
+func (s *ReportService) GetBasketReport(startDate, endDate time.Time) (*models.BasketReport, error) {
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("%w: report range ends before it starts", ErrValidation)
+	}
+
+	report := &models.BasketReport{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		GeneratedAt:  time.Now(),
+		Active:       []models.SectorTrades{},
+		ExpiringFrom: endDate.Add(time.Nanosecond),
+		ExpiringTo:   endDate.AddDate(0, 0, 7),
+	}
+
+	rating, err := s.market.GetRatingAsOf(endDate)
+	if err != nil {
+		return nil, err
+	}
+	report.Rating = rating
+
+	active, err := s.trades.GetActiveTradesByDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	report.Active = groupBySector(active, rating)
+
+	report.Expiring, err = s.trades.queryAllTrades(models.TradeQuery{
+		Statuses:       []string{models.StatusActive, models.StatusAdjusted},
+		ExpirationFrom: report.ExpiringFrom,
+		ExpirationTo:   report.ExpiringTo,
+		SortBy:         models.SortExpirationDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pnl, err := s.trades.GetRealizedPnL(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	report.PnL = *pnl
+
+	return report, nil
+}
+
+// WriteBasketReport renders the basket review for a date range to w as a PDF
+func (s *ReportService) WriteBasketReport(w io.Writer, startDate, endDate time.Time) (*models.ReportSummary, error) {
+	report, err := s.GetBasketReport(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := renderBasketReport(report)
+	if err := doc.Write(w); err != nil {
+		return nil, err
+	}
+
+	summary := &models.ReportSummary{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Pages:       doc.Pages(),
+		Expiring:    len(report.Expiring),
+		RealizedPnL: report.PnL.RealizedPnL,
+	}
+	for _, sector := range report.Active {
+		summary.ActiveTrades += len(sector.Trades)
+	}
+	return summary, nil
+}
+
+// groupBySector splits trades by sector, standard sectors first and then any
+// others alphabetically, each sorted by expiration
+func groupBySector(trades []models.OptionsTrade, rating *models.MarketRating) []models.SectorTrades {
+	bySector := map[string][]models.OptionsTrade{}
+	for _, trade := range trades {
+		bySector[trade.Sector] = append(bySector[trade.Sector], trade)
+	}
+
+	order := models.GetSectorNames()
+	listed := map[string]bool{}
+	for _, sector := range order {
+		listed[sector] = true
+	}
+	var extra []string
+	for sector := range bySector {
+		if !listed[sector] {
+			extra = append(extra, sector)
+		}
+	}
+	sort.Strings(extra)
+
+	groups := []models.SectorTrades{}
+	for _, sector := range append(order, extra...) {
+		sectorTrades := bySector[sector]
+		if len(sectorTrades) == 0 {
+			continue
+		}
+		sort.SliceStable(sectorTrades, func(i, j int) bool {
+			return sectorTrades[i].ExpirationDate.Before(sectorTrades[j].ExpirationDate)
+		})
+		group := models.SectorTrades{Sector: sector, Trades: sectorTrades}
+		if rating != nil {
+			if v, ok := rating.SectorRatings[sector]; ok {
+				group.Rating = &v
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// renderBasketReport lays out the basket review
+func renderBasketReport(report *models.BasketReport) *pdf.Document {
+	period := formatPeriod(report.StartDate, report.EndDate)
+	doc := pdf.New("Basket Review " + period)
+
+	doc.Space(14)
+	doc.Text(pdf.Margin, doc.Y(), pdf.HelveticaBold, 20, pdf.Black, "Basket Review")
+	doc.Space(8)
+	doc.Paragraph(pdf.Helvetica, 10, pdf.Gray, period+"  |  generated "+report.GeneratedAt.Format("Mon Jan 2, 2006 15:04"))
+
+	// Days to expiration are counted from the end of the range, or from now
+	// for a range that has not finished yet
+	asOf := report.EndDate
+	if report.GeneratedAt.Before(asOf) {
+		asOf = report.GeneratedAt
+	}
+
+	renderRatingDials(doc, report.Rating, report.EndDate)
+	renderActiveTrades(doc, report.Active, asOf)
+	renderExpiringTrades(doc, report)
+	renderRealizedPnL(doc, report.PnL)
+
+	doc.AddFooters("Trading Dashboard basket review, " + period)
+	return doc
+}
+
+func renderRatingDials(doc *pdf.Document, rating *models.MarketRating, endDate time.Time) {
+	doc.Heading("Market View")
+	if rating == nil {
+		doc.Paragraph(pdf.Helvetica, 10, pdf.Gray, "No market rating was recorded by "+endDate.Format("Jan 2, 2006")+".")
+		return
+	}
+	doc.Paragraph(pdf.Helvetica, 9, pdf.Gray, "Ratings saved "+rating.CreatedAt.Local().Format("Mon Jan 2, 2006 15:04")+", on a scale of -3 to +3.")
+	doc.Space(6)
+
+	const rowHeight = 20.0
+	columnWidth := (pdf.PageWidth - 2*pdf.Margin) / 2
+
+	doc.EnsureSpace(rowHeight)
+	drawDial(doc, pdf.Margin, doc.Y(), columnWidth, "Overall market", rating.OverallRating, true)
+	doc.Space(rowHeight + 4)
+
+	sectors := models.GetSectorNames()
+	for i, sector := range sectors {
+		if i%2 == 0 {
+			doc.EnsureSpace(rowHeight)
+		}
+		x := pdf.Margin + float64(i%2)*columnWidth
+		if v, ok := rating.SectorRatings[sector]; ok {
+			drawDial(doc, x, doc.Y(), columnWidth, sector, v, false)
+		} else {
+			doc.Text(x, doc.Y()+11, pdf.Helvetica, 9, pdf.Gray, sector+": not rated")
+		}
+		if i%2 == 1 || i == len(sectors)-1 {
+			doc.Space(rowHeight)
+		}
+	}
+}
+
+// drawDial draws a rating as a marker on a -3 to +3 scale, colored red for
+// bearish through gray to green for bullish like the dials in the app
+func drawDial(doc *pdf.Document, x, y, width float64, label string, value float64, bold bool) {
+	const labelWidth, valueWidth, barHeight = 120.0, 30.0, 4.0
+	font := pdf.Helvetica
+	if bold {
+		font = pdf.HelveticaBold
+	}
+	doc.Text(x, y+11, font, 9, pdf.Black, pdf.Truncate(font, 9, labelWidth-6, label))
+
+	barX := x + labelWidth
+	barWidth := width - labelWidth - valueWidth - 12
+	barY := y + 7
+	doc.Rect(barX, barY, barWidth, barHeight, pdf.LightGray)
+	for tick := -3; tick <= 3; tick++ {
+		tx := barX + float64(tick+3)/6*barWidth
+		doc.Line(tx, barY-2, tx, barY+barHeight+2, 0.5, pdf.Gray)
+	}
+	position := math.Max(-3, math.Min(3, value))
+	doc.Circle(barX+(position+3)/6*barWidth, barY+barHeight/2, 5, ratingColor(value))
+
+	doc.Text(barX+barWidth+10, y+11, pdf.HelveticaBold, 9, ratingColor(value), formatRating(value))
+}
+
+// ratingColor mirrors the app's dial colors: red to orange below zero,
+// gray at zero and yellow to green above
+func ratingColor(value float64) pdf.Color {
+	intensity := math.Min(math.Abs(value)/3, 1)
+	switch {
+	case value < 0:
+		return hslColor(20-intensity*20, 0.8, 0.5)
+	case value > 0:
+		return hslColor(60+intensity*60, 0.8, 0.4)
+	default:
+		return pdf.Gray
+	}
+}
+
+// hslColor converts a hue in degrees and saturation and lightness from 0 to
+// 1 to RGB
+func hslColor(h, s, l float64) pdf.Color {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	default:
+		r, g, b = 0, c, x
+	}
+	return pdf.Color{R: r + m, G: g + m, B: b + m}
+}
+
+func renderActiveTrades(doc *pdf.Document, sectors []models.SectorTrades, asOf time.Time) {
+	doc.Heading("Active Trades by Sector")
+	if len(sectors) == 0 {
+		doc.Paragraph(pdf.Helvetica, 10, pdf.Gray, "No open trades in this period.")
+		return
+	}
+
+	columns := []pdf.Column{
+		{Title: "Ticker", Width: 55},
+		{Title: "Strategy", Width: 140},
+		{Title: "Status", Width: 60},
+		{Title: "Entry", Width: 65},
+		{Title: "Expiration", Width: 65},
+		{Title: "DTE", Width: 31, Align: pdf.AlignRight},
+		{Title: "Target", Width: 50, Align: pdf.AlignRight},
+		{Title: "Stop", Width: 50, Align: pdf.AlignRight},
+	}
+
+	for _, sector := range sectors {
+		title := fmt.Sprintf("%s (%d)", sector.Sector, len(sector.Trades))
+		if sector.Rating != nil {
+			title += "  rated " + formatRating(*sector.Rating)
+		}
+		doc.EnsureSpace(50)
+		doc.Space(6)
+		doc.Paragraph(pdf.HelveticaBold, 10, pdf.Black, title)
+		doc.Space(2)
+
+		rows := make([][]string, 0, len(sector.Trades))
+		for _, trade := range sector.Trades {
+			rows = append(rows, []string{
+				trade.Ticker,
+				trade.StrategyType,
+				trade.Status,
+				trade.EntryDate.Format("Jan 2, 2006"),
+				trade.ExpirationDate.Format("Jan 2, 2006"),
+				fmt.Sprintf("%d", daysUntil(asOf, trade.ExpirationDate)),
+				formatPrice(trade.TargetPrice),
+				formatPrice(trade.StopLoss),
+			})
+		}
+		doc.Table(columns, rows, nil)
+	}
+}
+
+func renderExpiringTrades(doc *pdf.Document, report *models.BasketReport) {
+	doc.Heading("Expiring " + formatPeriod(report.ExpiringFrom, report.ExpiringTo))
+	if len(report.Expiring) == 0 {
+		doc.Paragraph(pdf.Helvetica, 10, pdf.Gray, "No open trades expire in the following week.")
+		return
+	}
+
+	columns := []pdf.Column{
+		{Title: "Ticker", Width: 55},
+		{Title: "Sector", Width: 130},
+		{Title: "Strategy", Width: 140},
+		{Title: "Expires", Width: 91},
+		{Title: "Legs", Width: 40, Align: pdf.AlignRight},
+		{Title: "Target", Width: 60, Align: pdf.AlignRight},
+	}
+	rows := make([][]string, 0, len(report.Expiring))
+	for _, trade := range report.Expiring {
+		rows = append(rows, []string{
+			trade.Ticker,
+			trade.Sector,
+			trade.StrategyType,
+			trade.ExpirationDate.Format("Mon Jan 2"),
+			fmt.Sprintf("%d", len(trade.Legs)),
+			formatPrice(trade.TargetPrice),
+		})
+	}
+	doc.Table(columns, rows, nil)
+}
+
+func renderRealizedPnL(doc *pdf.Document, pnl models.PnLSummary) {
+	doc.Heading("Realized P&L")
+	if len(pnl.Trades) == 0 {
+		doc.Paragraph(pdf.Helvetica, 10, pdf.Gray, "Nothing was closed or expired in this period.")
+		return
+	}
+
+	doc.Paragraph(pdf.HelveticaBold, 11, pnlColor(pnl.RealizedPnL), formatMoney(pnl.RealizedPnL)+" realized")
+	doc.Paragraph(pdf.Helvetica, 9, pdf.Gray, fmt.Sprintf("Trades: %d, winners: %d, losers: %d. Includes %s in fees.",
+		len(pnl.Trades), pnl.Winners, pnl.Losers, formatMoney(pnl.Fees)))
+	doc.Space(6)
+
+	columns := []pdf.Column{
+		{Title: "Ticker", Width: 60},
+		{Title: "Strategy", Width: 150},
+		{Title: "Status", Width: 70},
+		{Title: "Closed", Width: 60, Align: pdf.AlignRight},
+		{Title: "Realized", Width: 90, Align: pdf.AlignRight},
+		{Title: "Fees", Width: 86, Align: pdf.AlignRight},
+	}
+	rows := make([][]string, 0, len(pnl.Trades))
+	for _, trade := range pnl.Trades {
+		rows = append(rows, []string{
+			trade.Ticker,
+			trade.StrategyType,
+			trade.Status,
+			fmt.Sprintf("%d", trade.ClosedQuantity),
+			formatMoney(trade.RealizedPnL),
+			formatMoney(trade.Fees),
+		})
+	}
+	doc.Table(columns, rows, func(row, col int) pdf.Color {
+		if col == 4 {
+			return pnlColor(pnl.Trades[row].RealizedPnL)
+		}
+		return pdf.Black
+	})
+}
+
+func pnlColor(v float64) pdf.Color {
+	switch {
+	case v > 0:
+		return pdf.Green
+	case v < 0:
+		return pdf.Red
+	default:
+		return pdf.Black
+	}
+}
+
+// daysUntil counts calendar days from the day of from to the day of to
+func daysUntil(from, to time.Time) int {
+	y, m, d := from.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = to.Date()
+	end := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// formatPeriod formats an inclusive date range such as "Mar 17 – Mar 23, 2025"
+func formatPeriod(start, end time.Time) string {
+	if start.Year() == end.Year() {
+		return start.Format("Jan 2") + " – " + end.Format("Jan 2, 2006")
+	}
+	return start.Format("Jan 2, 2006") + " – " + end.Format("Jan 2, 2006")
+}
+
+func formatRating(v float64) string {
+	if v > 0 {
+		return fmt.Sprintf("+%g", v)
+	}
+	return fmt.Sprintf("%g", v)
+}
+
+func formatPrice(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", *v)
+}
+
+// formatMoney formats dollars with thousands separators, e.g. -$1,234.50
+func formatMoney(v float64) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	whole := fmt.Sprintf("%.2f", v)
+	intPart, frac := whole[:len(whole)-3], whole[len(whole)-3:]
+
+	var b strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return sign + "$" + b.String() + frac
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// RunScenario revalues every open trade under each price move and volatility
+// shift of the request's grid, plus any shock to its sector, DaysForward days
+// after asOf. P&L is measured against the trades' value at asOf under the
+// unshocked quotes, so it includes the time decay of moving forward.
+func (s *PortfolioService) RunScenario(req models.ScenarioRequest, asOf time.Time) (*models.ScenarioResult, error) {
+	priceMoves := req.PriceMoves
+	if len(priceMoves) == 0 {
+		priceMoves = models.DefaultPriceMoves
+	}
+	volShifts := req.VolShifts
+	if len(volShifts) == 0 {
+		volShifts = models.DefaultVolShifts
+	}
+	priceMoves, volShifts = sortedSteps(priceMoves), sortedSteps(volShifts)
+
+	trades, err := s.openTrades()
+	if err != nil {
+		return nil, err
+	}
+	shocks, err := validateScenario(req, priceMoves, volShifts, trades)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := normalizeQuotes(req.Quotes)
+	valuedAt := asOf.AddDate(0, 0, req.DaysForward)
+	result := &models.ScenarioResult{
+		AsOf:         asOf,
+		ValuedAt:     valuedAt,
+		DaysForward:  req.DaysForward,
+		PriceMoves:   priceMoves,
+		VolShifts:    volShifts,
+		SectorShocks: req.SectorShocks,
+		Total:        newScenarioGrid("Portfolio", len(priceMoves), len(volShifts)),
+		BySector:     []models.ScenarioGrid{},
+		Unpriced:     []string{},
+	}
+	if result.SectorShocks == nil {
+		result.SectorShocks = []models.SectorShock{}
+	}
+	sectors := map[string]*models.ScenarioGrid{}
+	unpriced := map[string]bool{}
+
+	for _, trade := range trades {
+		ticker := strings.ToUpper(trade.Ticker)
+		quote, ok := quotes[ticker]
+		if !ok || quote.Price <= 0 || quote.Volatility < 0 {
+			unpriced[ticker] = true
+			continue
+		}
+
+		base, err := positionValue(trade, quote.Price, quote.Volatility, req.Rate, asOf)
+		if err != nil {
+			return nil, err
+		}
+
+		sector, ok := sectors[trade.Sector]
+		if !ok {
+			grid := newScenarioGrid(trade.Sector, len(priceMoves), len(volShifts))
+			sector = &grid
+			sectors[trade.Sector] = sector
+		}
+		sector.Trades++
+		result.Total.Trades++
+
+		shock := shocks[trade.Sector]
+		for i, move := range priceMoves {
+			price := quote.Price * (1 + move + shock.PriceMove)
+			for j, shift := range volShifts {
+				vol := math.Max(0, quote.Volatility+shift+shock.VolShift)
+				value, err := positionValue(trade, price, vol, req.Rate, valuedAt)
+				if err != nil {
+					return nil, err
+				}
+				pnl := value - base
+				sector.PnL[i][j] += pnl
+				result.Total.PnL[i][j] += pnl
+			}
+		}
+	}
+
+	labels := make([]string, 0, len(sectors))
+	for label := range sectors {
+		labels = append(labels, label)
+	}
+	for _, label := range orderLabels(labels, models.GetSectorNames()) {
+		grid := sectors[label]
+		setWorstCell(grid, priceMoves, volShifts)
+		result.BySector = append(result.BySector, *grid)
+	}
+	setWorstCell(&result.Total, priceMoves, volShifts)
+
+	worst := 0.0
+	for _, grid := range result.BySector {
+		if grid.WorstPnL < worst {
+			worst = grid.WorstPnL
+			result.WorstSector = grid.Label
+		}
+	}
+
+	for ticker := range unpriced {
+		result.Unpriced = append(result.Unpriced, ticker)
+	}
+	sort.Strings(result.Unpriced)
+
+	return result, nil
+}
+
+// validateScenario checks the grid and sector shocks of a request and returns
+// the shocks by sector. Shocked sectors must be standard sectors or the
+// sector of an open trade, so a misspelled sector is not silently ignored.
+func validateScenario(req models.ScenarioRequest, priceMoves, volShifts []float64, trades []models.OptionsTrade) (map[string]models.SectorShock, error) {
+	if len(priceMoves) > models.MaxScenarioSteps || len(volShifts) > models.MaxScenarioSteps {
+		return nil, fmt.Errorf("%w: at most %d price moves and %d volatility shifts are allowed", ErrValidation, models.MaxScenarioSteps, models.MaxScenarioSteps)
+	}
+	if req.DaysForward < 0 || req.DaysForward > models.MaxScenarioDaysForward {
+		return nil, fmt.Errorf("%w: days forward must be between 0 and %d", ErrValidation, models.MaxScenarioDaysForward)
+	}
+	if req.Rate < -1 || req.Rate > 1 {
+		return nil, fmt.Errorf("%w: rate must be a decimal between -1 and 1", ErrValidation)
+	}
+
+	known := map[string]bool{}
+	for _, sector := range models.GetSectorNames() {
+		known[sector] = true
+	}
+	for _, trade := range trades {
+		known[trade.Sector] = true
+	}
+
+	lowest := priceMoves[0]
+	if lowest <= -1 {
+		return nil, fmt.Errorf("%w: a price move of %.0f%% would take prices to zero", ErrValidation, lowest*100)
+	}
+	shocks := map[string]models.SectorShock{}
+	for _, shock := range req.SectorShocks {
+		if !known[shock.Sector] {
+			return nil, fmt.Errorf("%w: unknown sector %q", ErrValidation, shock.Sector)
+		}
+		if _, dup := shocks[shock.Sector]; dup {
+			return nil, fmt.Errorf("%w: sector %q is shocked more than once", ErrValidation, shock.Sector)
+		}
+		if lowest+shock.PriceMove <= -1 {
+			return nil, fmt.Errorf("%w: a %.0f%% %s shock on top of a %.0f%% move would take prices to zero",
+				ErrValidation, shock.PriceMove*100, shock.Sector, lowest*100)
+		}
+		shocks[shock.Sector] = shock
+	}
+	return shocks, nil
+}
+
+// positionValue returns the value of a trade's legs with the underlying at
+// price and volatility vol. Short legs count negatively.
+func positionValue(trade models.OptionsTrade, price, vol, rate float64, asOf time.Time) (float64, error) {
+	inputs := models.GreeksRequest{UnderlyingPrice: price, Volatility: vol, Rate: rate}
+	value := 0.0
+	for _, leg := range trade.Legs {
+		g, err := legGreeks(leg, inputs, asOf)
+		if err != nil {
+			return 0, fmt.Errorf("failed to value %s leg %d: %w", trade.Ticker, leg.ID, err)
+		}
+		value += g.Price
+	}
+	return value, nil
+}
+
+func newScenarioGrid(label string, rows, cols int) models.ScenarioGrid {
+	pnl := make([][]float64, rows)
+	for i := range pnl {
+		pnl[i] = make([]float64, cols)
+	}
+	return models.ScenarioGrid{Label: label, PnL: pnl}
+}
+
+// setWorstCell records the lowest P&L of a grid and the scenario that
+// produced it
+func setWorstCell(grid *models.ScenarioGrid, priceMoves, volShifts []float64) {
+	first := true
+	for i, row := range grid.PnL {
+		for j, pnl := range row {
+			if first || pnl < grid.WorstPnL {
+				first = false
+				grid.WorstPnL = pnl
+				grid.WorstPriceMove = priceMoves[i]
+				grid.WorstVolShift = volShifts[j]
+			}
+		}
+	}
+}
+
+// sortedSteps returns a sorted copy of a grid axis without duplicates
+func sortedSteps(steps []float64) []float64 {
+	sorted := append([]float64(nil), steps...)
+	sort.Float64s(sorted)
+	unique := make([]float64, 0, len(sorted))
+	for _, step := range sorted {
+		if len(unique) == 0 || step != unique[len(unique)-1] {
+			unique = append(unique, step)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SettingsService stores application settings as key/value pairs
+type SettingsService struct {
+	db *sql.DB
+}
+
+// NewSettingsService creates a new settings service
+func NewSettingsService(db *sql.DB) *SettingsService {
+	return &SettingsService{db: db}
+}
+
+// GetSettings returns every stored setting
+func (s *SettingsService) GetSettings() (map[string]string, error) {
+	rows, err := s.db.Query("SELECT key, value FROM settings ORDER BY key")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := map[string]string{}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan setting: %w", err)
+		}
+		settings[key] = value
+	}
+	return settings, rows.Err()
+}
+
+// GetSetting returns a setting's value and whether it is set
+func (s *SettingsService) GetSetting(key string) (string, bool, error) {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// SetSetting stores a setting, replacing any previous value
+func (s *SettingsService) SetSetting(key, value string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("%w: setting key is required", ErrValidation)
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO settings (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP
+	`, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to save setting %s: %w", key, err)
+	}
+	return nil
+}
+
+// DeleteSetting removes a setting so its default applies again
+func (s *SettingsService) DeleteSetting(key string) error {
+	if _, err := s.db.Exec("DELETE FROM settings WHERE key = ?", key); err != nil {
+		return fmt.Errorf("failed to delete setting %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/payoff"
+)
+
+// SizingService turns the account's risk budget and the market ratings into
+// trade sizes
+type SizingService struct {
+	market   *MarketService
+	settings *SettingsService
+}
+
+// NewSizingService creates a new sizing service
+func NewSizingService(db *sql.DB) *SizingService {
+	return &SizingService{
+		market:   NewMarketService(db),
+		settings: NewSettingsService(db),
+	}
+}
+
+// SizePosition returns how many units of a spread to trade. A full-size trade
+// risks MaxRiskPercent of the account; directional trades are scaled by the
+// conviction of their sector's rating (see models.ConvictionScale) and get
+// nothing when the rating is zero or points the other way. Neutral trades
+// are not scaled. Risk per unit is the spread's maximum loss at expiration,
+// so spreads with unlimited risk cannot be sized.
+func (s *SizingService) SizePosition(req models.SizingRequest) (*models.SizingResult, error) {
+	sector := strings.TrimSpace(req.Sector)
+	if sector == "" {
+		return nil, fmt.Errorf("%w: sector is required", ErrValidation)
+	}
+	if req.Direction != "" && !models.IsValidDirection(req.Direction) {
+		return nil, fmt.Errorf("%w: invalid direction: %s", ErrValidation, req.Direction)
+	}
+
+	equity, riskPercent, err := s.riskSettings(req)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, err := payoff.Analyze(req.Legs, payoff.Options{Volatility: req.Volatility, Rate: req.Rate})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	if analysis.MaxLossUnlimited {
+		return nil, fmt.Errorf("%w: the spread's risk is unlimited; only defined-risk spreads can be sized", ErrValidation)
+	}
+	riskPerUnit := -analysis.MaxLoss
+	if riskPerUnit <= 0 {
+		return nil, fmt.Errorf("%w: the spread has no loss to size against", ErrValidation)
+	}
+
+	result := &models.SizingResult{
+		Sector:         sector,
+		Direction:      req.Direction,
+		AccountEquity:  equity,
+		MaxRiskPercent: riskPercent,
+		MaxRisk:        equity * riskPercent / 100,
+		RiskPerUnit:    riskPerUnit,
+	}
+	if result.Direction == "" {
+		result.Direction = inferDirection(analysis)
+		result.DirectionInferred = true
+	}
+
+	rating, err := s.market.GetLatestRating()
+	if err != nil {
+		return nil, err
+	}
+	if rating != nil {
+		if v, ok := rating.SectorRatings[sector]; ok {
+			result.Rating, result.RatingSource = &v, "sector"
+		} else {
+			overall := rating.OverallRating
+			result.Rating, result.RatingSource = &overall, "overall"
+		}
+	}
+
+	result.Scale, result.Reason = sizingScale(result.Direction, result.Rating)
+	result.RiskBudget = result.MaxRisk * result.Scale
+	// The epsilon keeps an exact fit from rounding down a unit
+	result.Contracts = int(math.Floor(result.RiskBudget/riskPerUnit + 1e-9))
+	result.RiskUsed = float64(result.Contracts) * riskPerUnit
+	if result.Contracts == 0 && result.Scale > 0 {
+		result.Reason = fmt.Sprintf("one unit risks $%.2f, more than the $%.2f budget", riskPerUnit, result.RiskBudget)
+	}
+
+	return result, nil
+}
+
+// riskSettings returns the account equity and risk percentage of a request,
+// falling back to the stored settings
+func (s *SizingService) riskSettings(req models.SizingRequest) (float64, float64, error) {
+	equity, err := s.floatSetting(req.AccountEquity, models.SettingAccountEquity, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	if equity <= 0 {
+		return 0, 0, fmt.Errorf("%w: account equity is required; pass it or store the %s setting", ErrValidation, models.SettingAccountEquity)
+	}
+
+	riskPercent, err := s.floatSetting(req.MaxRiskPercent, models.SettingMaxRiskPercent, models.DefaultMaxRiskPercent)
+	if err != nil {
+		return 0, 0, err
+	}
+	if riskPercent <= 0 || riskPercent > 100 {
+		return 0, 0, fmt.Errorf("%w: max risk per trade must be between 0 and 100 percent", ErrValidation)
+	}
+	return equity, riskPercent, nil
+}
+
+// floatSetting returns value if non-zero, else the stored setting key, else
+// def
+func (s *SizingService) floatSetting(value float64, key string, def float64) (float64, error) {
+	if value != 0 {
+		return value, nil
+	}
+	stored, ok, err := s.settings.GetSetting(key)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return def, nil
+	}
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(stored), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: setting %s is not a number: %q", ErrValidation, key, stored)
+	}
+	return parsed, nil
+}
+
+// sizingScale returns the share of full size allowed for a direction under a
+// rating, with the reason when it is less than full. The rating only scales
+// directional trades; neutral trades always get full size.
+func sizingScale(direction string, rating *float64) (float64, string) {
+	if direction == models.DirectionNeutral {
+		return 1, ""
+	}
+	if rating == nil {
+		return 0, "no market rating has been recorded"
+	}
+	r := *rating
+
+	switch {
+	case r == 0:
+		return 0, "no new directional trades while the rating is 0"
+	case (r > 0) != (direction == models.DirectionBullish):
+		return 0, fmt.Sprintf("%s trade against a rating of %+g", direction, r)
+	}
+	scale := models.ConvictionScale(r)
+	if scale < 1 {
+		return scale, fmt.Sprintf("sized to %.0f%% for a rating of %+g", scale*100, r)
+	}
+	return scale, ""
+}
+
+// inferDirection reads a spread's direction from the ends of its payoff
+// curve: losing on one side and making money on the other is directional,
+// anything else is neutral
+func inferDirection(analysis *payoff.Analysis) string {
+	curve := analysis.Expiration
+	if len(analysis.NearTerm) > 0 {
+		curve = analysis.NearTerm
+	}
+	if len(curve) < 2 {
+		return models.DirectionNeutral
+	}
+
+	low, high := curve[0].PnL, curve[len(curve)-1].PnL
+	switch {
+	case low < 0 && high > 0:
+		return models.DirectionBullish
+	case low > 0 && high < 0:
+		return models.DirectionBearish
+	default:
+		return models.DirectionNeutral
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// TradeExternalIDExists reports whether a trade was already imported under
+// the given broker order ID
+func (s *TradeService) TradeExternalIDExists(externalID string) (bool, error) {
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM options_trades WHERE external_id = ?", externalID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check trade external ID: %w", err)
+	}
+	return count > 0, nil
+}
+
+// FillExternalIDExists reports whether a fill was already imported under the
+// given broker execution ID
+func (s *TradeService) FillExternalIDExists(externalID string) (bool, error) {
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM trade_fills WHERE external_id = ?", externalID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check fill external ID: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetOpenTrades retrieves every active or adjusted trade, oldest first
+func (s *TradeService) GetOpenTrades() ([]models.OptionsTrade, error) {
+	rows, err := s.db.Query(`
+		SELECT ` + tradeColumns + `
+		FROM options_trades
+		WHERE ` + openStatusSQL + `
+		ORDER BY entry_date, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open trades: %w", err)
+	}
+	defer rows.Close()
+
+	trades := []models.OptionsTrade{}
+	for rows.Next() {
+		trade, err := scanTrade(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, *trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.attachLegs(trades)
+}
+
+// LatestSectorForTicker returns the sector of the most recent trade in a
+// ticker, or "" if it was never traded
+func (s *TradeService) LatestSectorForTicker(ticker string) (string, error) {
+	var sector string
+	err := s.db.QueryRow(`
+		SELECT COALESCE((
+			SELECT sector FROM options_trades
+			WHERE ticker = ?
+			ORDER BY entry_date DESC, id DESC
+			LIMIT 1
+		), '')
+	`, ticker).Scan(&sector)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up sector: %w", err)
+	}
+	return sector, nil
+}
+
+// ApplyImport writes the non-duplicate trades and closing fills of an import
+// preview in a single transaction. A closing fill that empties a trade marks
+// it closed; a close of only some legs marks it adjusted without a fill.
+func (s *TradeService) ApplyImport(preview *models.ImportPreview) (*models.ImportResult, error) {
+	result := &models.ImportResult{
+		CreatedTrades: []int64{},
+		ClosedTrades:  []int64{},
+		Warnings:      []string{},
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	created := make(map[string]int64)
+	for _, t := range preview.Trades {
+		if t.Duplicate {
+			result.SkippedDuplicates++
+			continue
+		}
+		if err := models.ValidateTradeRequest(t.Request); err != nil {
+			return nil, fmt.Errorf("%w: trade %s: %w", ErrValidation, t.ExternalID, err)
+		}
+
+		id, err := insertTrade(tx, t.Request)
+		if err != nil {
+			return nil, fmt.Errorf("trade %s: %w", t.ExternalID, err)
+		}
+		if _, err := insertFill(tx, id, t.OpenFill); err != nil {
+			return nil, fmt.Errorf("trade %s: %w", t.ExternalID, err)
+		}
+		created[t.ExternalID] = id
+		result.CreatedTrades = append(result.CreatedTrades, id)
+		result.RecordedFills++
+	}
+
+	for _, c := range preview.Closes {
+		if c.Duplicate {
+			result.SkippedDuplicates++
+			continue
+		}
+
+		tradeID := c.TradeID
+		if c.TradeExternalID != "" {
+			tradeID = created[c.TradeExternalID]
+		}
+		if tradeID == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: no matching open trade, skipped", c.ExternalID))
+			continue
+		}
+
+		if c.Adjustment {
+			status, err := tradeStatus(tx, tradeID)
+			if err != nil {
+				return nil, err
+			}
+			if status == models.StatusActive {
+				if _, err := setStatus(tx, tradeID, models.StatusAdjusted, models.ReasonAdjusted, time.Now()); err != nil {
+					return nil, err
+				}
+			}
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%s: closes only some legs of trade %d; marked adjusted without a closing fill", c.ExternalID, tradeID))
+			continue
+		}
+
+		remaining, err := remainingQuantity(tx, tradeID)
+		if err != nil {
+			return nil, err
+		}
+
+		fill := c.Fill
+		switch {
+		case remaining == 0:
+			// Trades entered by hand before fills existed have nothing to
+			// close against; record the outcome without the fill
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%s: trade %d has no recorded opening fill; marked closed without a closing fill", c.ExternalID, tradeID))
+		case fill.Quantity > remaining:
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%s: closing quantity %d reduced to open quantity %d on trade %d", c.ExternalID, fill.Quantity, remaining, tradeID))
+			fill.Quantity = remaining
+			fallthrough
+		default:
+			if _, err := insertFill(tx, tradeID, fill); err != nil {
+				return nil, fmt.Errorf("%s: %w", c.ExternalID, err)
+			}
+			result.RecordedFills++
+			remaining -= fill.Quantity
+		}
+
+		if remaining == 0 {
+			status, err := tradeStatus(tx, tradeID)
+			if err != nil {
+				return nil, err
+			}
+			if !models.IsOpenStatus(status) {
+				continue
+			}
+			if _, err := setStatus(tx, tradeID, models.StatusClosed, models.ReasonImported, time.Now()); err != nil {
+				return nil, err
+			}
+			result.ClosedTrades = append(result.ClosedTrades, tradeID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,446 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// AddFill records an opening or closing fill against an open trade
+func (s *TradeService) AddFill(tradeID int64, req models.FillRequest) (*models.Fill, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, err := insertFill(tx, tradeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetFillByID(id)
+}
+
+// CloseTrade records a closing fill and marks the trade as closed once no
+// open quantity remains; a partial close leaves the trade open. A zero
+// quantity closes the entire remaining position and an empty side offsets
+// the opening fills.
+func (s *TradeService) CloseTrade(tradeID int64, req models.FillRequest) (*models.OptionsTrade, error) {
+	req.Action = models.FillActionClose
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if req.Quantity == 0 {
+		remaining, err := remainingQuantity(tx, tradeID)
+		if err != nil {
+			return nil, err
+		}
+		if remaining == 0 {
+			return nil, fmt.Errorf("%w: trade has no open quantity to close", ErrValidation)
+		}
+		req.Quantity = remaining
+	}
+	if req.Side == "" {
+		side, err := closingSide(tx, tradeID)
+		if err != nil {
+			return nil, err
+		}
+		req.Side = side
+	}
+
+	if _, err := insertFill(tx, tradeID, req); err != nil {
+		return nil, err
+	}
+
+	remaining, err := remainingQuantity(tx, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	if remaining == 0 {
+		if _, err := setStatus(tx, tradeID, models.StatusClosed, models.ReasonClosed, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetTradeByID(tradeID)
+}
+
+// GetFillByID retrieves a fill by ID
+func (s *TradeService) GetFillByID(id int64) (*models.Fill, error) {
+	query := `
+		SELECT id, trade_id, action, side, price, quantity, fees, filled_at,
+		       COALESCE(external_id, ''), created_at
+		FROM trade_fills
+		WHERE id = ?
+	`
+
+	var fill models.Fill
+	err := s.db.QueryRow(query, id).Scan(
+		&fill.ID,
+		&fill.TradeID,
+		&fill.Action,
+		&fill.Side,
+		&fill.Price,
+		&fill.Quantity,
+		&fill.Fees,
+		&fill.FilledAt,
+		&fill.ExternalID,
+		&fill.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("fill %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get fill: %w", err)
+	}
+
+	return &fill, nil
+}
+
+// GetFills retrieves all fills for a trade in chronological order
+func (s *TradeService) GetFills(tradeID int64) ([]models.Fill, error) {
+	rows, err := s.db.Query(`
+		SELECT id, trade_id, action, side, price, quantity, fees, filled_at,
+		       COALESCE(external_id, ''), created_at
+		FROM trade_fills
+		WHERE trade_id = ?
+		ORDER BY filled_at, id
+	`, tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fills: %w", err)
+	}
+	defer rows.Close()
+
+	fills := []models.Fill{}
+	for rows.Next() {
+		var fill models.Fill
+		err := rows.Scan(
+			&fill.ID,
+			&fill.TradeID,
+			&fill.Action,
+			&fill.Side,
+			&fill.Price,
+			&fill.Quantity,
+			&fill.Fees,
+			&fill.FilledAt,
+			&fill.ExternalID,
+			&fill.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fill: %w", err)
+		}
+		fills = append(fills, fill)
+	}
+
+	return fills, rows.Err()
+}
+
+// DeleteFill deletes a fill from an open trade. An opening fill cannot be
+// deleted while the trade's closing fills depend on its quantity.
+func (s *TradeService) DeleteFill(id int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var tradeID int64
+	var action string
+	var quantity int
+	err = tx.QueryRow("SELECT trade_id, action, quantity FROM trade_fills WHERE id = ?", id).Scan(&tradeID, &action, &quantity)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("fill %w", ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get fill: %w", err)
+	}
+
+	status, err := tradeStatus(tx, tradeID)
+	if err != nil {
+		return err
+	}
+	if !models.IsOpenStatus(status) {
+		return fmt.Errorf("%w: fills can only be deleted from open trades; trade is %s", ErrValidation, status)
+	}
+
+	if action == models.FillActionOpen {
+		remaining, err := remainingQuantity(tx, tradeID)
+		if err != nil {
+			return err
+		}
+		if quantity > remaining {
+			return fmt.Errorf("%w: deleting this fill would leave more closed than opened; delete the closing fills first", ErrValidation)
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM trade_fills WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete fill: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetTradePnL computes realized P&L for a trade. When mark is provided it is
+// the current net per-share price to close the position, and unrealized P&L
+// is computed for the remaining quantity.
+func (s *TradeService) GetTradePnL(tradeID int64, mark *float64) (*models.TradePnL, error) {
+	trade, err := s.GetTradeByID(tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	fills, err := s.GetFills(tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	pnl := calculatePnL(*trade, fills, mark, nil)
+	return &pnl, nil
+}
+
+// GetRealizedPnL computes realized P&L from closing fills (and expirations)
+// that occurred within the date range
+func (s *TradeService) GetRealizedPnL(startDate, endDate time.Time) (*models.PnLSummary, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT trade_id FROM trade_fills
+		WHERE action = 'close' AND filled_at >= ? AND filled_at <= ?
+		UNION
+		SELECT id FROM options_trades
+		WHERE status = 'expired' AND expiration_date >= ? AND expiration_date <= ?
+	`, startDate, endDate, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query realized trades: %w", err)
+	}
+
+	var tradeIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan trade ID: %w", err)
+		}
+		tradeIDs = append(tradeIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	summary := &models.PnLSummary{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Trades:    []models.TradePnL{},
+	}
+	within := func(t time.Time) bool {
+		return !t.Before(startDate) && !t.After(endDate)
+	}
+
+	for _, id := range tradeIDs {
+		trade, err := s.GetTradeByID(id)
+		if err != nil {
+			return nil, err
+		}
+		fills, err := s.GetFills(id)
+		if err != nil {
+			return nil, err
+		}
+
+		pnl := calculatePnL(*trade, fills, nil, within)
+		summary.Trades = append(summary.Trades, pnl)
+		summary.RealizedPnL += pnl.RealizedPnL
+		summary.Fees += pnl.Fees
+		if pnl.RealizedPnL > 0 {
+			summary.Winners++
+		} else if pnl.RealizedPnL < 0 {
+			summary.Losers++
+		}
+	}
+
+	return summary, nil
+}
+
+// calculatePnL applies average-cost accounting to a trade's fills. Each
+// closing fill realizes its own cash flow plus the average opening cash flow
+// of the quantity it closes. Quantity still open on an expired trade is
+// realized as expiring worthless on the expiration date. When within is
+// non-nil only closing events it accepts are realized.
+func calculatePnL(trade models.OptionsTrade, fills []models.Fill, mark *float64, within func(time.Time) bool) models.TradePnL {
+	pnl := models.TradePnL{
+		TradeID:      trade.ID,
+		Ticker:       trade.Ticker,
+		StrategyType: trade.StrategyType,
+		Status:       trade.Status,
+	}
+
+	openSide := ""
+	for _, fill := range fills {
+		if fill.Action == models.FillActionOpen {
+			pnl.OpenQuantity += fill.Quantity
+			pnl.CostBasis += fill.CashFlow()
+			if openSide == "" {
+				openSide = fill.Side
+			}
+		}
+	}
+
+	var avgOpen float64
+	if pnl.OpenQuantity > 0 {
+		avgOpen = pnl.CostBasis / float64(pnl.OpenQuantity)
+	}
+
+	for _, fill := range fills {
+		if fill.Action == models.FillActionOpen {
+			if within == nil || within(fill.FilledAt) {
+				pnl.Fees += fill.Fees
+			}
+			continue
+		}
+		pnl.ClosedQuantity += fill.Quantity
+		if within == nil || within(fill.FilledAt) {
+			pnl.RealizedPnL += fill.CashFlow() + avgOpen*float64(fill.Quantity)
+			pnl.Fees += fill.Fees
+		}
+	}
+
+	pnl.RemainingQuantity = pnl.OpenQuantity - pnl.ClosedQuantity
+	if pnl.RemainingQuantity < 0 {
+		pnl.RemainingQuantity = 0
+	}
+
+	if trade.Status == models.StatusExpired && pnl.RemainingQuantity > 0 {
+		if within == nil || within(trade.ExpirationDate) {
+			pnl.RealizedPnL += avgOpen * float64(pnl.RemainingQuantity)
+		}
+		pnl.ClosedQuantity += pnl.RemainingQuantity
+		pnl.RemainingQuantity = 0
+	}
+
+	if mark != nil && pnl.RemainingQuantity > 0 {
+		// Closing a credit position costs the mark; closing a debit position receives it
+		closing := *mark * models.ContractMultiplier
+		if openSide == models.SideSell {
+			closing = -closing
+		}
+		unrealized := (avgOpen + closing) * float64(pnl.RemainingQuantity)
+		pnl.UnrealizedPnL = &unrealized
+	}
+
+	return pnl
+}
+
+// insertFill validates and inserts a fill within a transaction
+func insertFill(tx *sql.Tx, tradeID int64, req models.FillRequest) (int64, error) {
+	if err := models.ValidateFillRequest(req); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	status, err := tradeStatus(tx, tradeID)
+	if err != nil {
+		return 0, err
+	}
+	if !models.IsOpenStatus(status) {
+		return 0, fmt.Errorf("%w: fills can only be recorded on open trades; trade is %s", ErrValidation, status)
+	}
+
+	if req.Action == models.FillActionClose {
+		remaining, err := remainingQuantity(tx, tradeID)
+		if err != nil {
+			return 0, err
+		}
+		if req.Quantity > remaining {
+			return 0, fmt.Errorf("%w: closing quantity %d exceeds open quantity %d", ErrValidation, req.Quantity, remaining)
+		}
+
+		side, err := openingSide(tx, tradeID)
+		if err != nil {
+			return 0, err
+		}
+		if req.Side == side {
+			return 0, fmt.Errorf("%w: a closing fill must offset the %s opening fills, not repeat their side", ErrValidation, side)
+		}
+	}
+
+	filledAt := req.FilledAt
+	if filledAt.IsZero() {
+		filledAt = time.Now()
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO trade_fills (trade_id, action, side, price, quantity, fees, filled_at, external_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, tradeID, req.Action, req.Side, req.Price, req.Quantity, req.Fees, filledAt, nullString(req.ExternalID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert fill: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fill ID: %w", err)
+	}
+	return id, nil
+}
+
+// remainingQuantity returns the open quantity not yet closed for a trade
+func remainingQuantity(tx *sql.Tx, tradeID int64) (int, error) {
+	var remaining int
+	err := tx.QueryRow(`
+		SELECT COALESCE(SUM(CASE WHEN action = 'open' THEN quantity ELSE -quantity END), 0)
+		FROM trade_fills
+		WHERE trade_id = ?
+	`, tradeID).Scan(&remaining)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get remaining quantity: %w", err)
+	}
+	return remaining, nil
+}
+
+// openingSide returns the side holding most of a trade's opening quantity,
+// or "" when the trade has no opening fills
+func openingSide(tx *sql.Tx, tradeID int64) (string, error) {
+	var side string
+	err := tx.QueryRow(`
+		SELECT side FROM trade_fills
+		WHERE trade_id = ? AND action = 'open'
+		GROUP BY side
+		ORDER BY SUM(quantity) DESC, side
+		LIMIT 1
+	`, tradeID).Scan(&side)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get opening side: %w", err)
+	}
+	return side, nil
+}
+
+// closingSide returns the side that offsets a trade's opening fills
+func closingSide(tx *sql.Tx, tradeID int64) (string, error) {
+	side, err := openingSide(tx, tradeID)
+	if err != nil {
+		return "", err
+	}
+	if side == models.SideBuy {
+		return models.SideSell, nil
+	}
+	return models.SideBuy, nil
+}
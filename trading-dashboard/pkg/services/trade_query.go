@@ -0,0 +1,263 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"trading-dashboard/pkg/models"
+)
+
+// sortColumn describes how a sort key is ordered and how its value is read
+// back for the cursor. Values are read as stored text rather than parsed
+// times so the cursor compares exactly against the column.
+type sortColumn struct {
+	expr    string
+	value   string
+	numeric bool
+}
+
+// sortColumns maps TradeQuery sort keys to SQL. Missing prices sort below
+// every real price.
+var sortColumns = map[string]sortColumn{
+	models.SortEntryDate:      {expr: "entry_date", value: "CAST(entry_date AS TEXT)"},
+	models.SortExpirationDate: {expr: "expiration_date", value: "CAST(expiration_date AS TEXT)"},
+	models.SortTicker:         {expr: "ticker", value: "ticker"},
+	models.SortSector:         {expr: "sector", value: "sector"},
+	models.SortStrategy:       {expr: "strategy_type", value: "strategy_type"},
+	models.SortStatus:         {expr: "status", value: "status"},
+	models.SortTargetPrice:    {expr: "COALESCE(target_price, -1)", value: "COALESCE(target_price, -1)", numeric: true},
+	models.SortStopLoss:       {expr: "COALESCE(stop_loss, -1)", value: "COALESCE(stop_loss, -1)", numeric: true},
+	models.SortCreatedAt:      {expr: "created_at", value: "CAST(created_at AS TEXT)"},
+	models.SortUpdatedAt:      {expr: "updated_at", value: "CAST(updated_at AS TEXT)"},
+}
+
+// queryCursor is the position after the last trade of a page
+type queryCursor struct {
+	SortBy string  `json:"s"`
+	Desc   bool    `json:"d"`
+	Text   string  `json:"t,omitempty"`
+	Number float64 `json:"n,omitempty"`
+	ID     int64   `json:"i"`
+}
+
+// QueryTrades returns one page of the trades matching a query. Pages are
+// keyed on the sort value and trade ID, so rows added or removed between
+// requests never repeat or skip the remaining results.
+func (s *TradeService) QueryTrades(q models.TradeQuery) (*models.TradePage, error) {
+	if err := models.ValidateTradeQuery(q); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	if q.SortBy == "" {
+		q.SortBy = models.SortEntryDate
+	}
+	if q.Limit == 0 {
+		q.Limit = models.DefaultQueryLimit
+	}
+	if q.Limit > models.MaxQueryLimit {
+		q.Limit = models.MaxQueryLimit
+	}
+	order := sortColumns[q.SortBy]
+
+	where, args := tradeQueryConditions(q)
+
+	if q.Cursor != "" {
+		cursor, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.SortBy != q.SortBy || cursor.Desc != q.SortDesc {
+			return nil, fmt.Errorf("%w: cursor belongs to a different sort order", ErrValidation)
+		}
+
+		var value any = cursor.Text
+		if order.numeric {
+			value = cursor.Number
+		}
+		op := ">"
+		if q.SortDesc {
+			op = "<"
+		}
+		where = append(where, fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", order.expr, op, order.expr, op))
+		args = append(args, value, value, cursor.ID)
+	}
+
+	direction := "ASC"
+	if q.SortDesc {
+		direction = "DESC"
+	}
+
+	query := `SELECT ` + tradeColumns + `, ` + order.value + ` FROM options_trades`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT ?", order.expr, direction, direction)
+	// One extra row tells whether another page follows
+	args = append(args, q.Limit+1)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
+	defer rows.Close()
+
+	trades := []models.OptionsTrade{}
+	var last queryCursor
+	hasMore := false
+	for rows.Next() {
+		cursor := queryCursor{SortBy: q.SortBy, Desc: q.SortDesc}
+		var value any = &cursor.Text
+		if order.numeric {
+			value = &cursor.Number
+		}
+		trade, err := scanTrade(extraColumns{rows, []any{value}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		if len(trades) == q.Limit {
+			hasMore = true
+			break
+		}
+		cursor.ID = trade.ID
+		last = cursor
+		trades = append(trades, *trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	trades, err = s.attachLegs(trades)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.TradePage{Trades: trades, HasMore: hasMore}
+	if hasMore {
+		if page.NextCursor, err = encodeCursor(last); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// queryAllTrades pages through every trade matching q, ignoring its limit
+// and cursor
+func (s *TradeService) queryAllTrades(q models.TradeQuery) ([]models.OptionsTrade, error) {
+	q.Limit = models.MaxQueryLimit
+	q.Cursor = ""
+	trades := []models.OptionsTrade{}
+	for {
+		page, err := s.QueryTrades(q)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, page.Trades...)
+		if !page.HasMore {
+			return trades, nil
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+// tradeQueryConditions turns the filters of a query into WHERE conditions
+func tradeQueryConditions(q models.TradeQuery) ([]string, []any) {
+	var where []string
+	var args []any
+
+	in := func(expr string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		where = append(where, expr+" IN ("+placeholders(len(values))+")")
+		for _, v := range values {
+			args = append(args, v)
+		}
+	}
+
+	tickers := make([]string, len(q.Tickers))
+	for i, ticker := range q.Tickers {
+		tickers[i] = strings.ToUpper(strings.TrimSpace(ticker))
+	}
+	in("UPPER(ticker)", tickers)
+	in("sector", q.Sectors)
+	in("strategy_type", q.Strategies)
+	in("status", q.Statuses)
+	if len(q.Categories) > 0 {
+		where = append(where, "strategy_type IN (SELECT name FROM strategy_types WHERE category IN ("+placeholders(len(q.Categories))+"))")
+		for _, category := range q.Categories {
+			args = append(args, category)
+		}
+	}
+
+	bound := func(cond string, value any, ok bool) {
+		if ok {
+			where = append(where, cond)
+			args = append(args, value)
+		}
+	}
+	bound("entry_date >= ?", q.EntryFrom, !q.EntryFrom.IsZero())
+	bound("entry_date <= ?", q.EntryTo, !q.EntryTo.IsZero())
+	bound("expiration_date >= ?", q.ExpirationFrom, !q.ExpirationFrom.IsZero())
+	bound("expiration_date <= ?", q.ExpirationTo, !q.ExpirationTo.IsZero())
+	// A trade is active during the range if it was entered before the range
+	// ends and expires after it starts
+	bound("expiration_date >= ?", q.ActiveFrom, !q.ActiveFrom.IsZero())
+	bound("entry_date <= ?", q.ActiveTo, !q.ActiveTo.IsZero())
+
+	price := func(cond string, value *float64) {
+		if value != nil {
+			bound(cond, *value, true)
+		}
+	}
+	price("target_price >= ?", q.TargetPriceMin)
+	price("target_price <= ?", q.TargetPriceMax)
+	price("stop_loss >= ?", q.StopLossMin)
+	price("stop_loss <= ?", q.StopLossMax)
+
+	if q.StrikeMin != nil || q.StrikeMax != nil {
+		cond := "EXISTS (SELECT 1 FROM trade_legs l WHERE l.trade_id = options_trades.id"
+		if q.StrikeMin != nil {
+			cond += " AND l.strike >= ?"
+			args = append(args, *q.StrikeMin)
+		}
+		if q.StrikeMax != nil {
+			cond += " AND l.strike <= ?"
+			args = append(args, *q.StrikeMax)
+		}
+		where = append(where, cond+")")
+	}
+
+	return where, args
+}
+
+// placeholders returns n comma-separated SQL placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// encodeCursor serializes a cursor as an opaque URL-safe string
+func encodeCursor(c queryCursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor parses a cursor produced by encodeCursor
+func decodeCursor(s string) (queryCursor, error) {
+	var c queryCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: invalid cursor", ErrValidation)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("%w: invalid cursor", ErrValidation)
+	}
+	if _, ok := sortColumns[c.SortBy]; !ok {
+		return c, fmt.Errorf("%w: invalid cursor", ErrValidation)
+	}
+	return c, nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// RollTrade closes an open trade and opens its replacement in a single
+// transaction. The old trade is marked rolled and the new trade records it
+// as its parent. A trade with open fills can only be rolled by a closing
+// fill covering its whole remaining quantity.
+func (s *TradeService) RollTrade(tradeID int64, req models.RollRequest) (*models.OptionsTrade, error) {
+	old, err := s.GetTradeByID(tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := req.Trade
+	if next.Ticker == "" {
+		next.Ticker = old.Ticker
+	}
+	if next.Sector == "" {
+		next.Sector = old.Sector
+	}
+	if next.StrategyType == "" {
+		next.StrategyType = old.StrategyType
+	}
+	next.Status = models.StatusActive
+	next.ExternalID = ""
+
+	if err := models.ResolveLegSymbols(&next); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if err := models.ValidateTradeRequest(next); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := tradeStatus(tx, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	if !models.IsOpenStatus(status) {
+		return nil, fmt.Errorf("%w: only open trades can be rolled; trade is %s", ErrValidation, status)
+	}
+
+	if req.Close != nil {
+		closing := *req.Close
+		closing.Action = models.FillActionClose
+		closing.ExternalID = ""
+		if closing.Quantity == 0 {
+			remaining, err := remainingQuantity(tx, tradeID)
+			if err != nil {
+				return nil, err
+			}
+			if remaining == 0 {
+				return nil, fmt.Errorf("%w: trade has no open quantity to close", ErrValidation)
+			}
+			closing.Quantity = remaining
+		}
+		if _, err := insertFill(tx, tradeID, closing); err != nil {
+			return nil, err
+		}
+	}
+
+	// A rolled trade is finished, so nothing may be left open on it
+	remaining, err := remainingQuantity(tx, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	if remaining > 0 {
+		if req.Close == nil {
+			return nil, fmt.Errorf("%w: trade has %d open; a closing fill is required to roll it", ErrValidation, remaining)
+		}
+		return nil, fmt.Errorf("%w: the closing fill leaves %d open; close the whole position to roll it", ErrValidation, remaining)
+	}
+
+	newID, err := insertTrade(tx, next)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("UPDATE options_trades SET parent_trade_id = ? WHERE id = ?", tradeID, newID); err != nil {
+		return nil, fmt.Errorf("failed to link rolled trade: %w", err)
+	}
+
+	if req.Open != nil {
+		opening := *req.Open
+		opening.Action = models.FillActionOpen
+		opening.ExternalID = ""
+		if _, err := insertFill(tx, newID, opening); err != nil {
+			return nil, err
+		}
+	}
+
+	reason := fmt.Sprintf("rolled into trade #%d", newID)
+	if _, err := setStatus(tx, tradeID, models.StatusRolled, reason, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetTradeByID(newID)
+}
+
+// GetRollChain computes the P&L of the roll chain a trade belongs to, from
+// the original trade through every roll. When mark is provided it prices the
+// quantity still open in the chain.
+func (s *TradeService) GetRollChain(tradeID int64, mark *float64) (*models.RollChain, error) {
+	rootID, err := s.rootTradeID(tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// UNION rather than UNION ALL stops the walk if a cycle ever appears
+	rows, err := s.db.Query(`
+		WITH RECURSIVE chain(id) AS (
+			SELECT ?
+			UNION
+			SELECT t.id FROM options_trades t JOIN chain c ON t.parent_trade_id = c.id
+		)
+		SELECT id FROM chain ORDER BY id
+	`, rootID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roll chain: %w", err)
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan trade ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	chain := &models.RollChain{
+		RootTradeID: rootID,
+		Trades:      []models.TradePnL{},
+	}
+	for _, id := range ids {
+		pnl, err := s.GetTradePnL(id, mark)
+		if err != nil {
+			return nil, err
+		}
+		chain.Trades = append(chain.Trades, *pnl)
+		chain.RealizedPnL += pnl.RealizedPnL
+		chain.Fees += pnl.Fees
+		if pnl.UnrealizedPnL != nil {
+			unrealized := *pnl.UnrealizedPnL
+			if chain.UnrealizedPnL != nil {
+				unrealized += *chain.UnrealizedPnL
+			}
+			chain.UnrealizedPnL = &unrealized
+		}
+	}
+
+	return chain, nil
+}
+
+// rootTradeID follows parent links from a trade back to the original trade
+func (s *TradeService) rootTradeID(tradeID int64) (int64, error) {
+	seen := map[int64]bool{}
+	id := tradeID
+	for {
+		var parent sql.NullInt64
+		err := s.db.QueryRow("SELECT parent_trade_id FROM options_trades WHERE id = ?", id).Scan(&parent)
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("trade %w", ErrNotFound)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get parent trade: %w", err)
+		}
+
+		seen[id] = true
+		if !parent.Valid || seen[parent.Int64] {
+			return id, nil
+		}
+		id = parent.Int64
+	}
+}
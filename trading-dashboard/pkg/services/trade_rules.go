@@ -0,0 +1,192 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/payoff"
+)
+
+// RuleError is returned by CreateTrade when a blocking trade rule is broken.
+// It lists every violation, warnings included, and wraps ErrValidation.
+type RuleError struct {
+	Violations []models.RuleViolation
+}
+
+func (e *RuleError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, fmt.Sprintf("%s (%s)", v.Message, v.Severity))
+	}
+	return fmt.Sprintf("%v: blocked by trade rules: %s", ErrValidation, strings.Join(messages, "; "))
+}
+
+func (e *RuleError) Unwrap() error { return ErrValidation }
+
+// GetTradeRules returns the saved trade rules, with defaults for any rule
+// that was never saved
+func (s *TradeService) GetTradeRules() (models.TradeRules, error) {
+	rules := models.DefaultTradeRules()
+	stored, ok, err := NewSettingsService(s.db).GetSetting(models.SettingTradeRules)
+	if err != nil || !ok {
+		return rules, err
+	}
+	if err := json.Unmarshal([]byte(stored), &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse trade rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SaveTradeRules validates and stores the trade rules
+func (s *TradeService) SaveTradeRules(rules models.TradeRules) error {
+	if err := rules.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("failed to encode trade rules: %w", err)
+	}
+	return NewSettingsService(s.db).SetSetting(models.SettingTradeRules, string(data))
+}
+
+// CheckTradeRules reports the rules a trade request would break without
+// creating it. The request must pass the same validation as CreateTrade.
+func (s *TradeService) CheckTradeRules(req models.TradeRequest) (*models.RuleCheck, error) {
+	if err := models.ResolveLegSymbols(&req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if err := models.ValidateTradeRequest(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	return s.checkRules(req)
+}
+
+// checkRules evaluates every enabled rule against a validated request
+func (s *TradeService) checkRules(req models.TradeRequest) (*models.RuleCheck, error) {
+	rules, err := s.GetTradeRules()
+	if err != nil {
+		return nil, err
+	}
+
+	check := &models.RuleCheck{Violations: []models.RuleViolation{}}
+	violate := func(rule, severity, format string, args ...any) {
+		check.Violations = append(check.Violations, models.RuleViolation{
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+		if severity == models.SeverityBlock {
+			check.Blocked = true
+		}
+	}
+	ticker := strings.ToUpper(strings.TrimSpace(req.Ticker))
+	openStatuses := []string{models.StatusActive, models.StatusAdjusted}
+
+	if rule := rules.MaxOpenPerSector; rule.Severity != models.SeverityOff {
+		open, err := s.queryAllTrades(models.TradeQuery{Sectors: []string{req.Sector}, Statuses: openStatuses})
+		if err != nil {
+			return nil, err
+		}
+		if len(open)+1 > rule.Max {
+			violate(models.RuleMaxOpenPerSector, rule.Severity,
+				"%s already has %d open trade(s); the limit is %d", req.Sector, len(open), rule.Max)
+		}
+	}
+
+	if rule := rules.RatingDirection; rule.Severity != models.SeverityOff {
+		rating, err := NewMarketService(s.db).GetLatestRating()
+		if err != nil {
+			return nil, err
+		}
+		direction := tradeDirection(req)
+		if v, ok := sectorRating(rating, req.Sector); ok {
+			switch {
+			case direction == models.DirectionBullish && v < -rule.Threshold:
+				violate(models.RuleRatingDirection, rule.Severity,
+					"bullish %s in %s, rated %+g (below %+g)", req.StrategyType, req.Sector, v, -rule.Threshold)
+			case direction == models.DirectionBearish && v > rule.Threshold:
+				violate(models.RuleRatingDirection, rule.Severity,
+					"bearish %s in %s, rated %+g (above %+g)", req.StrategyType, req.Sector, v, rule.Threshold)
+			}
+		}
+	}
+
+	if rule := rules.DaysToExpiration; rule.Severity != models.SeverityOff {
+		dte := daysBetween(req.EntryDate, req.ExpirationDate)
+		if dte < rule.Min || dte > rule.Max {
+			violate(models.RuleDaysToExpiration, rule.Severity,
+				"%d days to expiration is outside %d-%d", dte, rule.Min, rule.Max)
+		}
+	}
+
+	if rule := rules.DuplicateTickerWeek; rule.Severity != models.SeverityOff {
+		open, err := s.queryAllTrades(models.TradeQuery{Tickers: []string{ticker}, Statuses: openStatuses})
+		if err != nil {
+			return nil, err
+		}
+		week, _ := models.ReportWeek(req.ExpirationDate)
+		for _, trade := range open {
+			if other, _ := models.ReportWeek(trade.ExpirationDate); other.Equal(week) {
+				violate(models.RuleDuplicateTickerWeek, rule.Severity,
+					"%s already has open trade #%d expiring the week of %s", ticker, trade.ID, week.Format("2006-01-02"))
+				break
+			}
+		}
+	}
+
+	return check, nil
+}
+
+// tradeDirection returns the direction of a seeded strategy type, reading
+// it from the legs' payoff for other strategies, or "" when neither tells
+func tradeDirection(req models.TradeRequest) string {
+	if direction := models.StrategyDirection(req.StrategyType); direction != "" {
+		return direction
+	}
+	if len(req.Legs) == 0 {
+		return ""
+	}
+
+	legs := make([]models.Leg, 0, len(req.Legs))
+	for _, leg := range req.Legs {
+		expiration := leg.ExpirationDate
+		if expiration.IsZero() {
+			expiration = req.ExpirationDate
+		}
+		legs = append(legs, models.Leg{
+			OptionType:     leg.OptionType,
+			Side:           leg.Side,
+			Strike:         leg.Strike,
+			ExpirationDate: expiration,
+			Quantity:       leg.Quantity,
+			Premium:        leg.Premium,
+		})
+	}
+	analysis, err := payoff.Analyze(legs, payoff.Options{})
+	if err != nil {
+		// Multi-expiration legs need a volatility to value
+		return ""
+	}
+	return inferDirection(analysis)
+}
+
+// sectorRating returns a sector's value in a rating snapshot
+func sectorRating(rating *models.MarketRating, sector string) (float64, bool) {
+	if rating == nil {
+		return 0, false
+	}
+	v, ok := rating.SectorRatings[sector]
+	return v, ok
+}
+
+// daysBetween counts the calendar days from one date to another
+func daysBetween(from, to time.Time) int {
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	return int(day(to).Sub(day(from)).Hours() / 24)
+}
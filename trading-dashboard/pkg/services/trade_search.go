@@ -0,0 +1,261 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"trading-dashboard/pkg/models"
+)
+
+// snippetRadius is how many bytes of notes a LIKE snippet keeps around the
+// first match
+const snippetRadius = 60
+
+// SearchTrades finds trades across the whole history whose ticker, sector,
+// strategy or notes match every term of query, best matches first. It uses
+// the FTS5 index when this build has it and falls back to LIKE otherwise.
+func (s *TradeService) SearchTrades(query string, filters models.TradeSearchFilters) ([]models.TradeSearchResult, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: search query is required", ErrValidation)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = models.DefaultSearchLimit
+	}
+	if filters.Limit > models.MaxSearchLimit {
+		filters.Limit = models.MaxSearchLimit
+	}
+
+	fullText, err := s.fullTextAvailable()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []models.TradeSearchResult
+	if fullText {
+		results, err = s.searchFullText(terms, filters)
+	} else {
+		results, err = s.searchLike(terms, filters)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]models.OptionsTrade, len(results))
+	for i, r := range results {
+		trades[i] = r.Trade
+	}
+	trades, err = s.attachLegs(trades)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Trade = trades[i]
+	}
+
+	return results, nil
+}
+
+// fullTextAvailable reports whether the FTS5 index is being kept in sync.
+// The database package drops its triggers when FTS5 is not compiled in.
+func (s *TradeService) fullTextAvailable() (bool, error) {
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name = 'trades_fts_insert'").Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check search index: %w", err)
+	}
+	return count > 0, nil
+}
+
+// searchFullText ranks matches with bm25, weighting the ticker highest
+func (s *TradeService) searchFullText(terms []string, filters models.TradeSearchFilters) ([]models.TradeSearchResult, error) {
+	// Quote every term so punctuation is never read as FTS syntax, and
+	// match prefixes so "earn" finds "earnings"
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+
+	where, args := searchFilterSQL(filters)
+	query := `
+		SELECT ` + tradeColumns + `, f.snip, f.score
+		FROM options_trades
+		JOIN (
+			SELECT rowid, snippet(trades_fts, -1, ?, ?, '…', 16) AS snip,
+			       bm25(trades_fts, 10.0, 2.0, 2.0, 1.0) AS score
+			FROM trades_fts
+			WHERE trades_fts MATCH ?
+		) f ON f.rowid = options_trades.id
+		WHERE 1 = 1` + where + `
+		ORDER BY f.score, entry_date DESC
+		LIMIT ?
+	`
+	args = append([]any{models.SnippetOpen, models.SnippetClose, strings.Join(quoted, " ")}, args...)
+	args = append(args, filters.Limit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search trades: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.TradeSearchResult{}
+	for rows.Next() {
+		var snippet string
+		var rank float64
+		trade, err := scanTrade(extraColumns{rows, []any{&snippet, &rank}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		r := models.TradeSearchResult{Trade: *trade, Snippet: snippet, Rank: rank}
+		results = append(results, r)
+	}
+
+	return results, rows.Err()
+}
+
+// searchLike matches substrings when FTS5 is unavailable. Ranks mimic the
+// FTS weighting: ticker hits count most, notes least; lower is better. The
+// rank is computed in SQL so the limit keeps the best matches.
+func (s *TradeService) searchLike(terms []string, filters models.TradeSearchFilters) ([]models.TradeSearchResult, error) {
+	var conditions strings.Builder
+	var args []any
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		conditions.WriteString(` AND (ticker LIKE ? ESCAPE '\' OR sector LIKE ? ESCAPE '\'
+			OR strategy_type LIKE ? ESCAPE '\' OR COALESCE(notes, '') LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern, pattern, pattern)
+	}
+
+	rank, rankArgs := likeRankSQL(terms)
+	where, filterArgs := searchFilterSQL(filters)
+	args = append(rankArgs, args...)
+	args = append(args, filterArgs...)
+	args = append(args, filters.Limit)
+
+	rows, err := s.db.Query(`
+		SELECT `+tradeColumns+`, `+rank+` AS score
+		FROM options_trades
+		WHERE 1 = 1`+conditions.String()+where+`
+		ORDER BY score, entry_date DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search trades: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.TradeSearchResult{}
+	for rows.Next() {
+		var rank float64
+		trade, err := scanTrade(extraColumns{rows, []any{&rank}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		results = append(results, models.TradeSearchResult{
+			Trade:   *trade,
+			Snippet: likeSnippet(*trade, terms),
+			Rank:    rank,
+		})
+	}
+
+	return results, rows.Err()
+}
+
+// searchFilterSQL turns the non-empty filters into AND conditions
+func searchFilterSQL(filters models.TradeSearchFilters) (string, []any) {
+	var where strings.Builder
+	var args []any
+	if filters.Status != "" {
+		where.WriteString(" AND status = ?")
+		args = append(args, filters.Status)
+	}
+	if filters.StrategyType != "" {
+		where.WriteString(" AND strategy_type = ?")
+		args = append(args, filters.StrategyType)
+	}
+	if filters.Sector != "" {
+		where.WriteString(" AND sector = ?")
+		args = append(args, filters.Sector)
+	}
+	if !filters.From.IsZero() {
+		where.WriteString(" AND entry_date >= ?")
+		args = append(args, filters.From)
+	}
+	if !filters.To.IsZero() {
+		where.WriteString(" AND entry_date <= ?")
+		args = append(args, filters.To)
+	}
+	return where.String(), args
+}
+
+// escapeLike escapes the LIKE wildcards in a search term
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
+
+// likeRankSQL builds an expression scoring a LIKE match with the same column
+// weights as the FTS index, negated so lower is better
+func likeRankSQL(terms []string) (string, []any) {
+	var rank strings.Builder
+	var args []any
+	rank.WriteString("(0")
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		rank.WriteString(`
+			- CASE WHEN UPPER(ticker) = ? THEN 20 WHEN ticker LIKE ? ESCAPE '\' THEN 10 ELSE 0 END
+			- CASE WHEN sector LIKE ? ESCAPE '\' THEN 2 ELSE 0 END
+			- CASE WHEN strategy_type LIKE ? ESCAPE '\' THEN 2 ELSE 0 END
+			- CASE WHEN COALESCE(notes, '') LIKE ? ESCAPE '\' THEN 1 ELSE 0 END`)
+		args = append(args, strings.ToUpper(term), pattern, pattern, pattern, pattern)
+	}
+	rank.WriteString(")")
+	return rank.String(), args
+}
+
+// likeSnippet excerpts the notes around the first matching term, or
+// highlights the ticker when only it matched
+func likeSnippet(trade models.OptionsTrade, terms []string) string {
+	notes := trade.Notes
+	lower := strings.ToLower(notes)
+	if len(lower) != len(notes) {
+		// Case folding changed byte offsets; match case-sensitively instead
+		lower = notes
+	}
+
+	start, end := -1, -1
+	for _, term := range terms {
+		if i := strings.Index(lower, strings.ToLower(term)); i >= 0 && (start < 0 || i < start) {
+			start, end = i, i+len(term)
+		}
+	}
+	if start < 0 {
+		for _, term := range terms {
+			if strings.Contains(strings.ToLower(trade.Ticker), strings.ToLower(term)) {
+				return models.SnippetOpen + trade.Ticker + models.SnippetClose
+			}
+		}
+		return ""
+	}
+
+	from := max(0, start-snippetRadius)
+	to := min(len(notes), end+snippetRadius)
+	// Avoid cutting a multi-byte character in half
+	for from > 0 && !utf8.RuneStart(notes[from]) {
+		from--
+	}
+	for to < len(notes) && !utf8.RuneStart(notes[to]) {
+		to++
+	}
+
+	snippet := notes[from:start] + models.SnippetOpen + notes[start:end] + models.SnippetClose + notes[end:to]
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(notes) {
+		snippet += "…"
+	}
+	return snippet
+}
@@ -8,6 +8,14 @@ import (
 	"trading-dashboard/pkg/models"
 )
 
+// openStatusSQL matches trades whose position is live (active or adjusted)
+const openStatusSQL = "status IN ('active', 'adjusted')"
+
+// tradeColumns lists the options_trades columns read by scanTrade
+const tradeColumns = `id, ticker, sector, strategy_type, entry_date, expiration_date,
+		       target_price, stop_loss, status, notes, COALESCE(external_id, ''), parent_trade_id,
+		       created_at, updated_at`
+
 type TradeService struct {
 	db *sql.DB
 }
@@ -17,81 +25,78 @@ func NewTradeService(db *sql.DB) *TradeService {
 	return &TradeService{db: db}
 }
 
-// CreateTrade creates a new options trade
+// CreateTrade creates a new options trade after checking it against the
+// trade rules. A broken blocking rule returns a *RuleError listing every
+// violation; broken warning rules are reported in the trade's Warnings.
 func (s *TradeService) CreateTrade(req models.TradeRequest) (*models.OptionsTrade, error) {
+	if err := models.ResolveLegSymbols(&req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
 	if err := models.ValidateTradeRequest(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	check, err := s.checkRules(req)
+	if err != nil {
+		return nil, err
+	}
+	if check.Blocked {
+		return nil, &RuleError{Violations: check.Violations}
 	}
 
-	query := `
-		INSERT INTO options_trades (
-			ticker, sector, strategy_type, entry_date, expiration_date,
-			target_price, stop_loss, notes
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`
-
-	result, err := s.db.Exec(
-		query,
-		req.Ticker,
-		req.Sector,
-		req.StrategyType,
-		req.EntryDate,
-		req.ExpirationDate,
-		req.TargetPrice,
-		req.StopLoss,
-		req.Notes,
-	)
+	tx, err := s.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create trade: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	id, err := result.LastInsertId()
+	id, err := insertTrade(tx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get trade ID: %w", err)
+		return nil, err
 	}
 
-	return s.GetTradeByID(id)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	trade, err := s.GetTradeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(check.Violations) > 0 {
+		trade.Warnings = check.Violations
+	}
+	return trade, nil
 }
 
 // GetTradeByID retrieves a trade by ID
 func (s *TradeService) GetTradeByID(id int64) (*models.OptionsTrade, error) {
 	query := `
-		SELECT id, ticker, sector, strategy_type, entry_date, expiration_date,
-		       target_price, stop_loss, status, notes, created_at, updated_at
+		SELECT ` + tradeColumns + `
 		FROM options_trades
 		WHERE id = ?
 	`
 
-	var trade models.OptionsTrade
-	err := s.db.QueryRow(query, id).Scan(
-		&trade.ID,
-		&trade.Ticker,
-		&trade.Sector,
-		&trade.StrategyType,
-		&trade.EntryDate,
-		&trade.ExpirationDate,
-		&trade.TargetPrice,
-		&trade.StopLoss,
-		&trade.Status,
-		&trade.Notes,
-		&trade.CreatedAt,
-		&trade.UpdatedAt,
-	)
+	trade, err := scanTrade(s.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("trade not found")
+			return nil, fmt.Errorf("trade %w", ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get trade: %w", err)
 	}
 
-	return &trade, nil
+	legs, err := s.getLegs(trade.ID, trade.Ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade legs: %w", err)
+	}
+	trade.Legs = legs
+
+	return trade, nil
 }
 
 // GetTrades retrieves trades within a date range
 func (s *TradeService) GetTrades(startDate, endDate time.Time) ([]models.OptionsTrade, error) {
 	query := `
-		SELECT id, ticker, sector, strategy_type, entry_date, expiration_date,
-		       target_price, stop_loss, status, notes, created_at, updated_at
+		SELECT ` + tradeColumns + `
 		FROM options_trades
 		WHERE entry_date >= ? AND entry_date <= ?
 		ORDER BY entry_date DESC, created_at DESC
@@ -105,37 +110,25 @@ func (s *TradeService) GetTrades(startDate, endDate time.Time) ([]models.Options
 
 	var trades []models.OptionsTrade
 	for rows.Next() {
-		var trade models.OptionsTrade
-		err := rows.Scan(
-			&trade.ID,
-			&trade.Ticker,
-			&trade.Sector,
-			&trade.StrategyType,
-			&trade.EntryDate,
-			&trade.ExpirationDate,
-			&trade.TargetPrice,
-			&trade.StopLoss,
-			&trade.Status,
-			&trade.Notes,
-			&trade.CreatedAt,
-			&trade.UpdatedAt,
-		)
+		trade, err := scanTrade(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan trade: %w", err)
 		}
-		trades = append(trades, trade)
+		trades = append(trades, *trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return trades, rows.Err()
+	return s.attachLegs(trades)
 }
 
 // GetActiveTradesByDateRange retrieves active trades for a specific date range (for calendar view)
 func (s *TradeService) GetActiveTradesByDateRange(startDate, endDate time.Time) ([]models.OptionsTrade, error) {
 	query := `
-		SELECT id, ticker, sector, strategy_type, entry_date, expiration_date,
-		       target_price, stop_loss, status, notes, created_at, updated_at
+		SELECT ` + tradeColumns + `
 		FROM options_trades
-		WHERE status = 'active' 
+		WHERE ` + openStatusSQL + `
 		  AND ((entry_date BETWEEN ? AND ?) 
 		       OR (expiration_date BETWEEN ? AND ?)
 		       OR (entry_date <= ? AND expiration_date >= ?))
@@ -150,35 +143,34 @@ func (s *TradeService) GetActiveTradesByDateRange(startDate, endDate time.Time)
 
 	var trades []models.OptionsTrade
 	for rows.Next() {
-		var trade models.OptionsTrade
-		err := rows.Scan(
-			&trade.ID,
-			&trade.Ticker,
-			&trade.Sector,
-			&trade.StrategyType,
-			&trade.EntryDate,
-			&trade.ExpirationDate,
-			&trade.TargetPrice,
-			&trade.StopLoss,
-			&trade.Status,
-			&trade.Notes,
-			&trade.CreatedAt,
-			&trade.UpdatedAt,
-		)
+		trade, err := scanTrade(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan trade: %w", err)
 		}
-		trades = append(trades, trade)
+		trades = append(trades, *trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return trades, rows.Err()
+	return s.attachLegs(trades)
 }
 
 // UpdateTrade updates an existing trade
 func (s *TradeService) UpdateTrade(id int64, req models.TradeRequest) (*models.OptionsTrade, error) {
+	req.Status = ""
+	if err := models.ResolveLegSymbols(&req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
 	if err := models.ValidateTradeRequest(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
 	query := `
 		UPDATE options_trades SET
@@ -187,7 +179,7 @@ func (s *TradeService) UpdateTrade(id int64, req models.TradeRequest) (*models.O
 		WHERE id = ?
 	`
 
-	result, err := s.db.Exec(
+	result, err := tx.Exec(
 		query,
 		req.Ticker,
 		req.Sector,
@@ -208,47 +200,83 @@ func (s *TradeService) UpdateTrade(id int64, req models.TradeRequest) (*models.O
 		return nil, fmt.Errorf("failed to get affected rows: %w", err)
 	}
 	if rowsAffected == 0 {
-		return nil, fmt.Errorf("trade not found")
+		return nil, fmt.Errorf("trade %w", ErrNotFound)
+	}
+
+	// Replace legs only when the request carries them
+	if req.Legs != nil {
+		if _, err := tx.Exec("DELETE FROM trade_legs WHERE trade_id = ?", id); err != nil {
+			return nil, fmt.Errorf("failed to delete existing trade legs: %w", err)
+		}
+		if err := insertLegs(tx, id, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return s.GetTradeByID(id)
 }
 
-// UpdateTradeStatus updates the status of a trade
+// UpdateTradeStatus moves a trade to a new lifecycle status
 func (s *TradeService) UpdateTradeStatus(id int64, status string) (*models.OptionsTrade, error) {
-	validStatuses := models.GetValidStatuses()
-	valid := false
-	for _, s := range validStatuses {
-		if s == status {
-			valid = true
-			break
-		}
-	}
-	if !valid {
-		return nil, fmt.Errorf("invalid status: %s", status)
-	}
+	return s.TransitionTrade(id, status, "")
+}
 
-	query := `UPDATE options_trades SET status = ? WHERE id = ?`
-	result, err := s.db.Exec(query, status, id)
+// TransitionTrade moves a trade to a new lifecycle status, rejecting illegal
+// transitions, and records the change with an optional reason
+func (s *TradeService) TransitionTrade(id int64, status, reason string) (*models.OptionsTrade, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to update trade status: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	if _, err := setStatus(tx, id, status, reason, time.Now()); err != nil {
+		return nil, err
 	}
-	if rowsAffected == 0 {
-		return nil, fmt.Errorf("trade not found")
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return s.GetTradeByID(id)
 }
 
-// DeleteTrade deletes a trade
+// DeleteTrade deletes a trade with its legs, fills, status history and alerts. Trades
+// rolled out of it are relinked to its own parent to keep the chain whole.
 func (s *TradeService) DeleteTrade(id int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM trade_legs WHERE trade_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete trade legs: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM trade_fills WHERE trade_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete trade fills: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM trade_status_history WHERE trade_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete trade status history: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM alerts WHERE trade_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete trade alerts: %w", err)
+	}
+	_, err = tx.Exec(`
+		UPDATE options_trades
+		SET parent_trade_id = (SELECT parent_trade_id FROM options_trades WHERE id = ?)
+		WHERE parent_trade_id = ?
+	`, id, id)
+	if err != nil {
+		return fmt.Errorf("failed to relink rolled trades: %w", err)
+	}
+
 	query := `DELETE FROM options_trades WHERE id = ?`
-	result, err := s.db.Exec(query, id)
+	result, err := tx.Exec(query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete trade: %w", err)
 	}
@@ -258,7 +286,11 @@ func (s *TradeService) DeleteTrade(id int64) error {
 		return fmt.Errorf("failed to get affected rows: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("trade not found")
+		return fmt.Errorf("trade %w", ErrNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -296,3 +328,155 @@ func (s *TradeService) GetStrategyTypes() ([]models.StrategyType, error) {
 
 	return strategies, rows.Err()
 }
+
+// insertTrade inserts a trade and its legs within a transaction
+func insertTrade(tx *sql.Tx, req models.TradeRequest) (int64, error) {
+	query := `
+		INSERT INTO options_trades (
+			ticker, sector, strategy_type, entry_date, expiration_date,
+			target_price, stop_loss, notes, external_id, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	status := req.Status
+	if status == "" {
+		status = models.StatusActive
+	}
+
+	result, err := tx.Exec(
+		query,
+		req.Ticker,
+		req.Sector,
+		req.StrategyType,
+		req.EntryDate,
+		req.ExpirationDate,
+		req.TargetPrice,
+		req.StopLoss,
+		req.Notes,
+		nullString(req.ExternalID),
+		status,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create trade: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trade ID: %w", err)
+	}
+
+	if err := insertLegs(tx, id, req); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// scanTrade scans a row selected with tradeColumns
+func scanTrade(row interface{ Scan(...any) error }) (*models.OptionsTrade, error) {
+	var trade models.OptionsTrade
+	err := row.Scan(
+		&trade.ID,
+		&trade.Ticker,
+		&trade.Sector,
+		&trade.StrategyType,
+		&trade.EntryDate,
+		&trade.ExpirationDate,
+		&trade.TargetPrice,
+		&trade.StopLoss,
+		&trade.Status,
+		&trade.Notes,
+		&trade.ExternalID,
+		&trade.ParentTradeID,
+		&trade.CreatedAt,
+		&trade.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &trade, nil
+}
+
+// extraColumns scans columns selected after tradeColumns into extra
+type extraColumns struct {
+	row   interface{ Scan(...any) error }
+	extra []any
+}
+
+func (r extraColumns) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, r.extra...)...)
+}
+
+// nullString maps an empty string to NULL so unique indexes ignore it
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// insertLegs inserts the legs of a trade request within a transaction
+func insertLegs(tx *sql.Tx, tradeID int64, req models.TradeRequest) error {
+	for i, leg := range req.Legs {
+		expiration := leg.ExpirationDate
+		if expiration.IsZero() {
+			expiration = req.ExpirationDate
+		}
+		_, err := tx.Exec(`
+			INSERT INTO trade_legs (
+				trade_id, option_type, side, strike, expiration_date, quantity, premium
+			) VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, tradeID, leg.OptionType, leg.Side, leg.Strike, expiration, leg.Quantity, leg.Premium)
+		if err != nil {
+			return fmt.Errorf("failed to insert leg %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// getLegs retrieves the legs of a trade, deriving option symbols from the
+// trade's ticker
+func (s *TradeService) getLegs(tradeID int64, ticker string) ([]models.Leg, error) {
+	rows, err := s.db.Query(`
+		SELECT id, trade_id, option_type, side, strike, expiration_date,
+		       quantity, premium, created_at
+		FROM trade_legs
+		WHERE trade_id = ?
+		ORDER BY id
+	`, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	legs := []models.Leg{}
+	for rows.Next() {
+		var leg models.Leg
+		err := rows.Scan(
+			&leg.ID,
+			&leg.TradeID,
+			&leg.OptionType,
+			&leg.Side,
+			&leg.Strike,
+			&leg.ExpirationDate,
+			&leg.Quantity,
+			&leg.Premium,
+			&leg.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		leg.Symbol = leg.OCCSymbol(ticker)
+		legs = append(legs, leg)
+	}
+
+	return legs, rows.Err()
+}
+
+// attachLegs loads the legs for each trade in the slice
+func (s *TradeService) attachLegs(trades []models.OptionsTrade) ([]models.OptionsTrade, error) {
+	for i := range trades {
+		legs, err := s.getLegs(trades[i].ID, trades[i].Ticker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get legs for trade %d: %w", trades[i].ID, err)
+		}
+		trades[i].Legs = legs
+	}
+	return trades, nil
+}
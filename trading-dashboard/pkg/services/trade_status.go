@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"trading-dashboard/pkg/models"
+)
+
+// ExpireOverdueTrades marks every open trade whose expiration has passed
+// the exchange close as expired and records each transition
+func (s *TradeService) ExpireOverdueTrades(now time.Time) ([]models.StatusChange, error) {
+	open, err := s.GetOpenTrades()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	changes := []models.StatusChange{}
+	for _, trade := range open {
+		if !trade.IsPastExpiration(now) {
+			continue
+		}
+
+		change, err := setStatus(tx, trade.ID, models.StatusExpired, models.ReasonExpired, now)
+		if err != nil {
+			return nil, err
+		}
+		if change == nil {
+			continue
+		}
+		changes = append(changes, *change)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return changes, nil
+}
+
+// RunExpirationSweeper expires overdue trades immediately and then on every
+// interval until ctx is cancelled. notify is called with each non-empty
+// batch of transitions.
+func (s *TradeService) RunExpirationSweeper(ctx context.Context, interval time.Duration, notify func([]models.StatusChange)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		changes, err := s.ExpireOverdueTrades(time.Now())
+		if err != nil {
+			log.Printf("Expiration sweep failed: %v", err)
+		} else if len(changes) > 0 {
+			log.Printf("Expiration sweep expired %d trade(s)", len(changes))
+			if notify != nil {
+				notify(changes)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetStatusHistory retrieves the status transitions of a trade, oldest first
+func (s *TradeService) GetStatusHistory(tradeID int64) ([]models.StatusChange, error) {
+	rows, err := s.db.Query(`
+		SELECT id, trade_id, from_status, to_status, reason, changed_at
+		FROM trade_status_history
+		WHERE trade_id = ?
+		ORDER BY changed_at, id
+	`, tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.StatusChange{}
+	for rows.Next() {
+		var change models.StatusChange
+		err := rows.Scan(
+			&change.ID,
+			&change.TradeID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.Reason,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
+
+// setStatus moves a trade to a new status within a transaction and records
+// the change. It returns nil without writing when the trade already has the
+// status, and ErrValidation when the lifecycle does not allow the move.
+// A finished trade has nothing left open: expiring records the remaining
+// quantity as a worthless closing fill, and every other finished status
+// requires the closing fills to be recorded first.
+func setStatus(tx *sql.Tx, tradeID int64, to, reason string, at time.Time) (*models.StatusChange, error) {
+	from, err := tradeStatus(tx, tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if from == to {
+		return nil, nil
+	}
+	if !models.CanTransition(from, to) {
+		return nil, fmt.Errorf("%w: cannot move trade from %s to %s", ErrValidation, from, to)
+	}
+
+	if models.IsFinishedStatus(to) {
+		remaining, err := remainingQuantity(tx, tradeID)
+		if err != nil {
+			return nil, err
+		}
+		if remaining > 0 {
+			if to != models.StatusExpired {
+				return nil, fmt.Errorf("%w: trade has %d open; record a closing fill before marking it %s", ErrValidation, remaining, to)
+			}
+			if err := expireRemaining(tx, tradeID, remaining); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE options_trades SET status = ? WHERE id = ?", to, tradeID); err != nil {
+		return nil, fmt.Errorf("failed to update trade status: %w", err)
+	}
+
+	return recordStatusChange(tx, tradeID, from, to, reason, at)
+}
+
+// expireRemaining closes the remaining quantity of a trade at zero on its
+// expiration date
+func expireRemaining(tx *sql.Tx, tradeID int64, remaining int) error {
+	var expiration time.Time
+	err := tx.QueryRow("SELECT expiration_date FROM options_trades WHERE id = ?", tradeID).Scan(&expiration)
+	if err != nil {
+		return fmt.Errorf("failed to get expiration date: %w", err)
+	}
+
+	side, err := closingSide(tx, tradeID)
+	if err != nil {
+		return err
+	}
+
+	_, err = insertFill(tx, tradeID, models.FillRequest{
+		Action:   models.FillActionClose,
+		Side:     side,
+		Quantity: remaining,
+		FilledAt: expiration,
+	})
+	return err
+}
+
+// tradeStatus reads the current status of a trade within a transaction
+func tradeStatus(tx *sql.Tx, tradeID int64) (string, error) {
+	var status string
+	err := tx.QueryRow("SELECT status FROM options_trades WHERE id = ?", tradeID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("trade %w", ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get trade status: %w", err)
+	}
+	return status, nil
+}
+
+// recordStatusChange inserts a status transition within a transaction
+func recordStatusChange(tx *sql.Tx, tradeID int64, from, to, reason string, at time.Time) (*models.StatusChange, error) {
+	result, err := tx.Exec(`
+		INSERT INTO trade_status_history (trade_id, from_status, to_status, reason, changed_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, tradeID, from, to, reason, at.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status change ID: %w", err)
+	}
+
+	return &models.StatusChange{
+		ID:         id,
+		TradeID:    tradeID,
+		FromStatus: from,
+		ToStatus:   to,
+		Reason:     reason,
+		ChangedAt:  at.UTC(),
+	}, nil
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"trading-dashboard/pkg/models"
+	"trading-dashboard/pkg/xlsx"
+)
+
+// WorkbookService exports trades, their legs, the rating history and
+// summary analytics to an Excel workbook
+type WorkbookService struct {
+	trades *TradeService
+	market *MarketService
+}
+
+// NewWorkbookService creates a new workbook export service
+func NewWorkbookService(db *sql.DB) *WorkbookService {
+	return &WorkbookService{
+		trades: NewTradeService(db),
+		market: NewMarketService(db),
+	}
+}
+
+// workbookTrade is an exported trade with its category and P&L
+type workbookTrade struct {
+	models.OptionsTrade
+	category string
+	pnl      models.TradePnL
+}
+
+// ExportWorkbook writes every trade matching q to w as an .xlsx workbook
+// with sheets for trades, legs, rating history and summary analytics. The
+// query's limit and cursor are ignored. Rating history covers the query's
+// entry date range; an open bound includes all ratings on that side.
+func (s *WorkbookService) ExportWorkbook(w io.Writer, q models.TradeQuery) (*models.WorkbookSummary, error) {
+	trades, err := s.collectTrades(q)
+	if err != nil {
+		return nil, err
+	}
+
+	to := q.EntryTo
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	ratings, err := s.market.GetRatingsBetween(q.EntryFrom, to)
+	if err != nil {
+		return nil, err
+	}
+
+	wb := xlsx.New()
+	summary := &models.WorkbookSummary{Trades: len(trades), Ratings: len(ratings)}
+	writeTradesSheet(wb.AddSheet("Trades"), trades)
+	summary.Legs = writeLegsSheet(wb.AddSheet("Legs"), trades)
+	writeRatingsSheet(wb.AddSheet("Rating History"), ratings)
+	writeSummarySheet(wb.AddSheet("Summary"), trades)
+
+	if err := wb.Write(w); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// collectTrades reads every trade matching q, attaching each one's
+// strategy category and realized P&L
+func (s *WorkbookService) collectTrades(q models.TradeQuery) ([]workbookTrade, error) {
+	strategies, err := s.trades.GetStrategyTypes()
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[string]string, len(strategies))
+	for _, st := range strategies {
+		categories[st.Name] = st.Category
+	}
+
+	found, err := s.trades.queryAllTrades(q)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]workbookTrade, 0, len(found))
+	for _, trade := range found {
+		fills, err := s.trades.GetFills(trade.ID)
+		if err != nil {
+			return nil, err
+		}
+		category := categories[trade.StrategyType]
+		if category == "" {
+			category = "Other"
+		}
+		trades = append(trades, workbookTrade{
+			OptionsTrade: trade,
+			category:     category,
+			pnl:          calculatePnL(trade, fills, nil, nil),
+		})
+	}
+	return trades, nil
+}
+
+func writeTradesSheet(sheet *xlsx.Sheet, trades []workbookTrade) {
+	sheet.SetHeader("ID", "Ticker", "Sector", "Strategy", "Category", "Status",
+		"Entry Date", "Expiration", "Target Price", "Stop Loss", "Legs",
+		"Realized P&L", "Fees", "Parent Trade", "External ID", "Notes",
+		"Created At", "Updated At")
+	sheet.SetColumnWidths(6, 9, 22, 22, 16, 10, 12, 12, 13, 12, 6, 14, 10, 12, 16, 40, 17, 17)
+
+	for _, t := range trades {
+		parent := xlsx.Empty()
+		if t.ParentTradeID != nil {
+			parent = xlsx.Int(*t.ParentTradeID)
+		}
+		sheet.AddRow(
+			xlsx.Int(t.ID),
+			xlsx.String(t.Ticker),
+			xlsx.String(t.Sector),
+			xlsx.String(t.StrategyType),
+			xlsx.String(t.category),
+			xlsx.String(t.Status),
+			xlsx.Date(t.EntryDate),
+			xlsx.Date(t.ExpirationDate),
+			xlsx.OptionalCurrency(t.TargetPrice),
+			xlsx.OptionalCurrency(t.StopLoss),
+			xlsx.Int(int64(len(t.Legs))),
+			xlsx.Currency(t.pnl.RealizedPnL),
+			xlsx.Currency(t.pnl.Fees),
+			parent,
+			xlsx.String(t.ExternalID),
+			xlsx.String(t.Notes),
+			xlsx.DateTime(t.CreatedAt.Local()),
+			xlsx.DateTime(t.UpdatedAt.Local()),
+		)
+	}
+}
+
+// writeLegsSheet lists every leg of the exported trades and returns how many
+// it wrote
+func writeLegsSheet(sheet *xlsx.Sheet, trades []workbookTrade) int {
+	sheet.SetHeader("Trade ID", "Ticker", "Leg ID", "Type", "Side", "Strike",
+		"Expiration", "Quantity", "Premium", "Symbol")
+	sheet.SetColumnWidths(9, 9, 7, 7, 6, 11, 12, 9, 11, 23)
+
+	count := 0
+	for _, t := range trades {
+		for _, leg := range t.Legs {
+			strike := xlsx.Currency(leg.Strike)
+			if leg.OptionType == models.OptionTypeStock {
+				strike = xlsx.Empty()
+			}
+			sheet.AddRow(
+				xlsx.Int(t.ID),
+				xlsx.String(t.Ticker),
+				xlsx.Int(leg.ID),
+				xlsx.String(leg.OptionType),
+				xlsx.String(leg.Side),
+				strike,
+				xlsx.Date(leg.ExpirationDate),
+				xlsx.Int(int64(leg.Quantity)),
+				xlsx.Currency(leg.Premium),
+				xlsx.String(leg.Symbol),
+			)
+			count++
+		}
+	}
+	return count
+}
+
+// writeRatingsSheet lists each rating snapshot with one column per sector.
+// The standard sectors come first, then any others found in the history.
+func writeRatingsSheet(sheet *xlsx.Sheet, ratings []models.MarketRating) {
+	sectors := models.GetSectorNames()
+	known := map[string]bool{}
+	for _, sector := range sectors {
+		known[sector] = true
+	}
+	var extra []string
+	for _, rating := range ratings {
+		for sector := range rating.SectorRatings {
+			if !known[sector] {
+				known[sector] = true
+				extra = append(extra, sector)
+			}
+		}
+	}
+	sort.Strings(extra)
+	sectors = append(sectors, extra...)
+
+	sheet.SetHeader(append([]string{"Recorded At", "Overall"}, sectors...)...)
+	widths := []float64{17, 9}
+	for range sectors {
+		widths = append(widths, 14)
+	}
+	sheet.SetColumnWidths(widths...)
+
+	for _, rating := range ratings {
+		row := []xlsx.Cell{xlsx.DateTime(rating.CreatedAt.Local()), xlsx.Number(rating.OverallRating)}
+		for _, sector := range sectors {
+			if v, ok := rating.SectorRatings[sector]; ok {
+				row = append(row, xlsx.Number(v))
+			} else {
+				row = append(row, xlsx.Empty())
+			}
+		}
+		sheet.AddRow(row...)
+	}
+}
+
+// writeSummarySheet writes totals for the exported trades and the outcomes of
+// the finished ones by strategy, category and sector
+func writeSummarySheet(sheet *xlsx.Sheet, trades []workbookTrade) {
+	sheet.SetHeader("Metric", "Value")
+	sheet.SetColumnWidths(24, 14, 10, 10, 10, 14, 14)
+
+	var finished []models.RatedTrade
+	var realized, fees float64
+	statuses := map[string]int{}
+	for _, t := range trades {
+		realized += t.pnl.RealizedPnL
+		fees += t.pnl.Fees
+		statuses[t.Status]++
+		if !models.IsFinishedStatus(t.Status) {
+			continue
+		}
+		rated := models.RatedTrade{
+			TradeID:      t.ID,
+			Ticker:       t.Ticker,
+			Sector:       t.Sector,
+			StrategyType: t.StrategyType,
+			Category:     t.category,
+			EntryDate:    t.EntryDate,
+			RealizedPnL:  t.pnl.RealizedPnL,
+			Outcome:      models.OutcomeFlat,
+		}
+		switch {
+		case rated.RealizedPnL > 0:
+			rated.Outcome = models.OutcomeWin
+		case rated.RealizedPnL < 0:
+			rated.Outcome = models.OutcomeLoss
+		}
+		finished = append(finished, rated)
+	}
+	overall := summarizeOutcomes("Finished trades", finished)
+
+	sheet.AddRow(xlsx.String("Exported At"), xlsx.DateTime(time.Now()))
+	sheet.AddRow(xlsx.String("Trades"), xlsx.Int(int64(len(trades))))
+	for _, status := range models.GetValidStatuses() {
+		if n := statuses[status]; n > 0 {
+			sheet.AddRow(xlsx.String(fmt.Sprintf("  %s", status)), xlsx.Int(int64(n)))
+		}
+	}
+	sheet.AddRow(xlsx.String("Finished Trades"), xlsx.Int(int64(overall.Trades)))
+	sheet.AddRow(xlsx.String("Winners"), xlsx.Int(int64(overall.Wins)))
+	sheet.AddRow(xlsx.String("Losers"), xlsx.Int(int64(overall.Losses)))
+	sheet.AddRow(xlsx.String("Win Rate"), xlsx.Percent(overall.WinRate))
+	// Realized P&L is already net of the fees shown beneath it
+	sheet.AddRow(xlsx.String("Realized P&L"), xlsx.Currency(realized))
+	sheet.AddRow(xlsx.String("Fees Paid"), xlsx.Currency(fees))
+
+	sections := []struct {
+		title string
+		key   func(models.RatedTrade) string
+	}{
+		{"Strategy", func(t models.RatedTrade) string { return t.StrategyType }},
+		{"Category", func(t models.RatedTrade) string { return t.Category }},
+		{"Sector", func(t models.RatedTrade) string { return t.Sector }},
+	}
+	for _, section := range sections {
+		sheet.AddRow()
+		sheet.AddRow(xlsx.Bold(section.title), xlsx.Bold("Trades"), xlsx.Bold("Wins"),
+			xlsx.Bold("Losses"), xlsx.Bold("Win Rate"), xlsx.Bold("Realized P&L"), xlsx.Bold("Average P&L"))
+		for _, bucket := range groupOutcomes(finished, section.key, nil) {
+			sheet.AddRow(
+				xlsx.String(bucket.Label),
+				xlsx.Int(int64(bucket.Trades)),
+				xlsx.Int(int64(bucket.Wins)),
+				xlsx.Int(int64(bucket.Losses)),
+				xlsx.Percent(bucket.WinRate),
+				xlsx.Currency(bucket.RealizedPnL),
+				xlsx.Currency(bucket.AveragePnL),
+			)
+		}
+	}
+}